@@ -15,10 +15,11 @@ export function forceSignOut(id) {
   })
 }
 
-export function deleteAttendance(id) {
+export function deleteAttendance(id, reason) {
   return request({
     url: `/leader/attendance/${id}`,
-    method: 'delete'
+    method: 'delete',
+    data: { reason }
   })
 }
 
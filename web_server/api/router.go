@@ -19,10 +19,15 @@ func Register(r *gin.Engine) {
 	pub.POST("/upload/image", controllers.UploadImage)
 	pub.GET("/clubs", controllers.ListClubs)
 	pub.GET("/clubs/:clubId", controllers.GetClubDetailPublic)
+	pub.GET("/clubs/:clubId/questions", controllers.ListApplicationQuestions)
+	pub.GET("/clubs/:clubId/alumni", controllers.ListClubAlumni)
+	pub.GET("/recruiting", controllers.ListRecruitingClubs)
 	pub.GET("/announcements", controllers.ListPublicAnnouncements)
 	pub.GET("/activities", controllers.ListPublicActivities)
 	pub.GET("/activities/:activityId", controllers.GetPublicActivityDetail)
 	pub.GET("/categories", controllers.ListCategories)
+	pub.GET("/terms", controllers.ListTerms)
+	pub.GET("/terms/current", controllers.GetCurrentTerm)
 
 	auth := v1.Group("")
 	auth.Use(middleware.JWT())
@@ -31,6 +36,17 @@ func Register(r *gin.Engine) {
 	student.POST("/clubs/:clubId/apply", controllers.ApplyJoinClub)
 	student.POST("/clubs/:clubId/exit", controllers.ExitClub)
 	student.GET("/memberships/my", controllers.MyMemberships)
+	student.GET("/memberships/history", controllers.MyClubHistory)
+	student.PUT("/clubs/:clubId/alumni-visibility", controllers.SetAlumniVisibility)
+	student.GET("/clubs/:clubId/events", controllers.MyMembershipEvents)
+	student.GET("/clubs/:clubId/interview-slots", controllers.ListOpenInterviewSlots)
+	student.GET("/clubs/:clubId/interview", controllers.MyInterview)
+	student.DELETE("/clubs/:clubId/interview", controllers.CancelInterview)
+	student.POST("/interview-slots/:id/book", controllers.BookInterviewSlot)
+	student.POST("/clubs/:clubId/renew", controllers.RenewMembership)
+	student.POST("/invites/redeem", controllers.RedeemInviteCode)
+	student.GET("/notifications", controllers.MyNotifications)
+	student.POST("/notifications/:id/read", controllers.ReadNotification)
 	student.GET("/me", controllers.MyProfile)
 	student.PUT("/me", controllers.UpdateMyProfile)
 	student.PUT("/password", controllers.ChangePassword)
@@ -40,12 +56,87 @@ func Register(r *gin.Engine) {
 	leader.GET("/users/:userId/clubs", controllers.GetUserLeaderClubs)
 	leader.POST("/clubs/:clubId/members/:userId/role", controllers.SetMemberRoleByLeader)
 	leader.DELETE("/clubs/:clubId/members/:userId", controllers.KickMember)
+	leader.GET("/clubs/:clubId/roles", controllers.ListClubRoles)
+	leader.POST("/clubs/:clubId/roles", controllers.CreateClubRole)
+	leader.PUT("/clubs/:clubId/roles/:id", controllers.UpdateClubRole)
+	leader.DELETE("/clubs/:clubId/roles/:id", controllers.DeleteClubRole)
+	leader.PUT("/clubs/:clubId/profile", controllers.UpdateClubProfile)
+	leader.GET("/clubs/:clubId/change-requests", controllers.ListClubChangeRequests)
+	leader.POST("/clubs/:clubId/change-requests/:id/cancel", controllers.CancelClubChangeRequest)
+	leader.GET("/clubs/:clubId/handovers", controllers.ListClubHandovers)
+	leader.POST("/clubs/:clubId/handovers", controllers.CreateHandover)
+	leader.POST("/handovers/:id/cancel", controllers.CancelHandover)
 	leader.GET("/clubs/:clubId/attendance", controllers.ClubAttendance)
 	leader.DELETE("/attendance/:id", controllers.DeleteAttendance)
+	leader.POST("/attendance/:id/restore", controllers.RestoreAttendance)
+	leader.GET("/clubs/:clubId/attendance/voided", controllers.ListVoidedAttendance)
+	leader.GET("/clubs/:clubId/attendance/policy", controllers.GetAttendancePolicy)
+	leader.PUT("/clubs/:clubId/attendance/policy", controllers.UpdateAttendancePolicy)
+	leader.GET("/clubs/:clubId/session-types", controllers.ListSessionTypes)
+	leader.POST("/clubs/:clubId/session-types", controllers.CreateSessionType)
+	leader.PUT("/clubs/:clubId/session-types/:id", controllers.UpdateSessionType)
+	leader.DELETE("/clubs/:clubId/session-types/:id", controllers.DeleteSessionType)
+	leader.PUT("/activities/:activityId/type", controllers.SetActivitySessionType)
+	leader.GET("/clubs/:clubId/mandatory-sessions", controllers.ListMandatorySessions)
+	leader.POST("/clubs/:clubId/mandatory-sessions", controllers.CreateMandatorySession)
+	leader.DELETE("/mandatory-sessions/:id", controllers.DeleteMandatorySession)
+	leader.POST("/mandatory-sessions/:id/absences/generate", controllers.GenerateSessionAbsences)
+	leader.GET("/mandatory-sessions/:id/absences", controllers.ListSessionAbsences)
+	leader.GET("/clubs/:clubId/absences/summary", controllers.ClubAbsenceSummary)
+	leader.GET("/clubs/:clubId/leave-requests", controllers.ListClubLeaveRequests)
+	leader.POST("/leave-requests/:id/approve", controllers.ApproveLeaveRequest)
+	leader.POST("/leave-requests/:id/reject", controllers.RejectLeaveRequest)
 	leader.GET("/clubs/:clubId/memberships", controllers.ListPendingMemberships)
+	leader.POST("/clubs/:clubId/questions", controllers.CreateApplicationQuestion)
+	leader.PUT("/clubs/:clubId/questions/:id", controllers.UpdateApplicationQuestion)
+	leader.DELETE("/clubs/:clubId/questions/:id", controllers.DeleteApplicationQuestion)
+	leader.GET("/clubs/:clubId/campaigns", controllers.ListCampaigns)
+	leader.POST("/clubs/:clubId/campaigns", controllers.CreateCampaign)
+	leader.PUT("/clubs/:clubId/campaigns/:id", controllers.UpdateCampaign)
+	leader.DELETE("/clubs/:clubId/campaigns/:id", controllers.DeleteCampaign)
+	leader.PUT("/clubs/:clubId/recruitment-settings", controllers.UpdateRecruitmentSettings)
+	leader.GET("/clubs/:clubId/campaigns/:id/slots", controllers.ListCampaignSlots)
+	leader.POST("/clubs/:clubId/campaigns/:id/slots", controllers.CreateInterviewSlot)
+	leader.PUT("/interview-slots/:id", controllers.UpdateInterviewSlot)
+	leader.DELETE("/interview-slots/:id", controllers.DeleteInterviewSlot)
+	leader.GET("/interview-slots/:id/interviews", controllers.ListSlotInterviews)
+	leader.POST("/interviews/:id/score", controllers.ScoreInterview)
+	leader.GET("/clubs/:clubId/renewals", controllers.ListPendingRenewals)
+	leader.POST("/clubs/:clubId/renewals/:id/approve", controllers.ApproveRenewal)
+	leader.POST("/clubs/:clubId/renewals/:id/reject", controllers.RejectRenewal)
+	leader.PUT("/clubs/:clubId/membership-settings", controllers.UpdateMembershipSettings)
+	leader.GET("/clubs/:clubId/dues/schedule", controllers.GetDuesSchedule)
+	leader.PUT("/clubs/:clubId/dues/schedule", controllers.UpdateDuesSchedule)
+	leader.DELETE("/clubs/:clubId/dues/schedule", controllers.DeleteDuesSchedule)
+	leader.GET("/clubs/:clubId/dues/payments", controllers.ListDuesPayments)
+	leader.POST("/clubs/:clubId/dues/payments", controllers.RecordDuesPayment)
+	leader.GET("/clubs/:clubId/dues/outstanding", controllers.ListOutstandingDues)
+	leader.GET("/clubs/:clubId/invites", controllers.ListInviteCodes)
+	leader.POST("/clubs/:clubId/invites", controllers.CreateInviteCode)
+	leader.POST("/clubs/:clubId/invites/:id/revoke", controllers.RevokeInviteCode)
+	leader.GET("/clubs/:clubId/invites/:id/redemptions", controllers.ListInviteRedemptions)
 	leader.POST("/clubs/:clubId/memberships/:id/approve", controllers.ApproveMembership)
 	leader.POST("/clubs/:clubId/memberships/:id/reject", controllers.RejectMembership)
+	leader.POST("/clubs/:clubId/memberships/batch-approve", controllers.BatchApproveMemberships)
+	leader.POST("/clubs/:clubId/roster/import", controllers.ImportRoster)
+	leader.POST("/clubs/:clubId/memberships/batch-reject", controllers.BatchRejectMemberships)
 	leader.GET("/clubs/:clubId/members/users", controllers.ListClubMembers)
+	leader.GET("/clubs/:clubId/tags", controllers.ListMemberTags)
+	leader.POST("/clubs/:clubId/tags", controllers.CreateMemberTag)
+	leader.PUT("/clubs/:clubId/tags/:id", controllers.UpdateMemberTag)
+	leader.DELETE("/clubs/:clubId/tags/:id", controllers.DeleteMemberTag)
+	leader.PUT("/clubs/:clubId/memberships/:id/tags", controllers.SetMemberTags)
+	leader.GET("/clubs/:clubId/memberships/:id/notes", controllers.ListMemberNotes)
+	leader.POST("/clubs/:clubId/memberships/:id/notes", controllers.CreateMemberNote)
+	leader.DELETE("/clubs/:clubId/memberships/:id/notes/:noteId", controllers.DeleteMemberNote)
+	leader.GET("/clubs/:clubId/memberships/:id/events", controllers.ListMemberEvents)
+	leader.GET("/clubs/:clubId/departments", controllers.ListDepartments)
+	leader.POST("/clubs/:clubId/departments", controllers.CreateDepartment)
+	leader.PUT("/clubs/:clubId/departments/:id", controllers.UpdateDepartment)
+	leader.DELETE("/clubs/:clubId/departments/:id", controllers.DeleteDepartment)
+	leader.GET("/clubs/:clubId/departments/:id/members", controllers.ListDepartmentMembers)
+	leader.POST("/clubs/:clubId/departments/:id/members", controllers.SetDepartmentMember)
+	leader.DELETE("/clubs/:clubId/departments/:id/members/:userId", controllers.RemoveDepartmentMember)
 
 	leader.GET("/clubs/:clubId/announcements", controllers.ListClubAnnouncements)
 	leader.POST("/clubs/:clubId/announcements", controllers.CreateAnnouncement)
@@ -57,13 +148,31 @@ func Register(r *gin.Engine) {
 	// 考勤管理相关接口
 	leader.GET("/attendance/list", controllers.ListManagedAttendance)
 	leader.POST("/attendance/:id/signout", controllers.ForceSignOut)
+	leader.GET("/attendance/stats", controllers.AttendanceStats)
+	leader.POST("/attendance/anomalies/scan", controllers.ScanAttendanceAnomalies)
+	leader.GET("/attendance/anomalies", controllers.ListAttendanceAnomalies)
+	leader.POST("/attendance/anomalies/:id/accept", controllers.AcceptAttendanceAnomaly)
+	leader.POST("/attendance/anomalies/:id/void", controllers.VoidAttendanceAnomaly)
 
 	admin := auth.Group("/admin")
 	admin.DELETE("/clubs/:clubId", controllers.DissolveClub)
 	admin.POST("/memberships/:id/role", controllers.UpdateMembershipRole)
 	admin.GET("/attendance", controllers.ListManagedAttendance) // 保留原有路由，指向新控制器
+	admin.GET("/attendance/stats", controllers.AttendanceStats)
+	admin.GET("/attendance/anomalies", controllers.ListAttendanceAnomalies)
 	admin.GET("/clubs/audit", controllers.ListPendingClubs)
 	admin.POST("/clubs/:id/audit", controllers.AuditClub)
+	admin.GET("/club-changes", controllers.ListAllClubChangeRequests)
+	admin.POST("/club-changes/:id/approve", controllers.ApproveClubChangeRequest)
+	admin.POST("/club-changes/:id/reject", controllers.RejectClubChangeRequest)
+	admin.GET("/terms", controllers.ListTerms)
+	admin.POST("/terms", controllers.CreateTerm)
+	admin.PUT("/terms/:id", controllers.UpdateTerm)
+	admin.DELETE("/terms/:id", controllers.DeleteTerm)
+	admin.GET("/handovers", controllers.ListHandovers)
+	admin.POST("/handovers/:id/confirm", controllers.ConfirmHandover)
+	admin.POST("/handovers/:id/reject", controllers.RejectHandover)
+	admin.PUT("/clubs/:clubId/handover-settings", controllers.UpdateHandoverSettings)
 
 	member.POST("/activities/:activityId/signin", controllers.SignIn)
 	member.POST("/activities/:activityId/signout", controllers.SignOut)
@@ -73,6 +182,17 @@ func Register(r *gin.Engine) {
 	member.POST("/clubs/:clubId/signin", controllers.ClubSignIn)
 	member.POST("/clubs/:clubId/signout", controllers.ClubSignOut)
 	member.GET("/attendance/my", controllers.MyAttendance)
+	member.POST("/attendance/sync", controllers.SyncAttendance)
+	member.POST("/devices", controllers.RegisterDevice)
+	member.GET("/absences/my", controllers.MyAbsences)
+	member.GET("/dues/my", controllers.MyDues)
+	member.GET("/handovers/my", controllers.MyHandovers)
+	member.POST("/handovers/:id/accept", controllers.AcceptHandover)
+	member.POST("/handovers/:id/decline", controllers.DeclineHandover)
+	member.POST("/leave-requests", controllers.CreateLeaveRequest)
+	member.GET("/leave-requests/my", controllers.MyLeaveRequests)
+	member.DELETE("/leave-requests/:id", controllers.CancelLeaveRequest)
+	member.GET("/clubs/:clubId/session-types", controllers.ListSessionTypes)
 	_ = leader
 	_ = admin
 }
@@ -14,16 +14,20 @@ type JWTConfig struct {
 }
 
 type Config struct {
-	DB     DBConfig
-	JWT    JWTConfig
-	Server ServerConfig
+	DB         DBConfig
+	JWT        JWTConfig
+	Server     ServerConfig
+	Attendance AttendanceConfig
+	Membership MembershipConfig
 }
 
 func Default() Config {
 	return Config{
-		DB:     DBConfig{Host: "8.138.158.24", Port: 3306, User: "user", Password: "zlsmh123456.", Name: "dachuang"},
-		JWT:    JWTConfig{Secret: "replace", Expires: 86400},
-		Server: ServerConfig{Addr: ":9000", BaseURL: "http://localhost:8080", PublicDir: "public", UploadDir: "uploads"},
+		DB:         DBConfig{Host: "8.138.158.24", Port: 3306, User: "user", Password: "zlsmh123456.", Name: "dachuang"},
+		JWT:        JWTConfig{Secret: "replace", Expires: 86400},
+		Server:     ServerConfig{Addr: ":9000", BaseURL: "http://localhost:8080", PublicDir: "public", UploadDir: "uploads"},
+		Attendance: AttendanceConfig{MaxSessionHours: 12, BurstCount: 10, BurstWindowSeconds: 60, WindowGraceMinutes: 30, SyncMaxAgeHours: 72, SyncMaxFutureSeconds: 300, MaxAbsences: 3},
+		Membership: MembershipConfig{RejectCooldownDays: 7, KickCooldownDays: 30},
 	}
 }
 
@@ -33,3 +37,21 @@ type ServerConfig struct {
 	PublicDir string
 	UploadDir string
 }
+
+// AttendanceConfig 考勤异常检测阈值、离线补传容差与缺勤阈值
+type AttendanceConfig struct {
+	MaxSessionHours    int // 单次时长超过该值视为异常
+	BurstCount         int // BurstWindowSeconds 内签到次数达到该值视为异常
+	BurstWindowSeconds int
+	WindowGraceMinutes int // 活动签到允许早于开始或晚于结束的分钟数
+	// 离线补传事件的客户端时间须在 [当前-SyncMaxAgeHours, 当前+SyncMaxFutureSeconds] 内
+	SyncMaxAgeHours      int
+	SyncMaxFutureSeconds int
+	MaxAbsences          int // 缺勤次数达到该值的成员被标记
+}
+
+// MembershipConfig 被驳回或移出社团后需等待的天数，期间不能再次申请或通过邀请码加入
+type MembershipConfig struct {
+	RejectCooldownDays int
+	KickCooldownDays   int
+}
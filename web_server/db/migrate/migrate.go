@@ -22,6 +22,35 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.Achievement{},
 		&models.ActivityParticipant{},
 		&models.OperationLog{},
+		&models.AttendancePolicy{},
+		&models.AttendanceFlag{},
+		&models.Term{},
+		&models.SessionType{},
+		&models.Device{},
+		&models.AttendanceSyncEvent{},
+		&models.MandatorySession{},
+		&models.MandatoryRosterEntry{},
+		&models.Absence{},
+		&models.LeaveRequest{},
+		&models.ApplicationQuestion{},
+		&models.ApplicationAnswer{},
+		&models.RecruitmentCampaign{},
+		&models.InterviewSlot{},
+		&models.Interview{},
+		&models.Notification{},
+		&models.DuesSchedule{},
+		&models.DuesPayment{},
+		&models.InviteCode{},
+		&models.InviteRedemption{},
+		&models.LeaderHandover{},
+		&models.Department{},
+		&models.DepartmentMember{},
+		&models.ClubRole{},
+		&models.MemberTag{},
+		&models.MembershipTag{},
+		&models.MemberNote{},
+		&models.MembershipEvent{},
+		&models.ClubChangeRequest{},
 	)
 }
 
@@ -198,3 +227,10 @@ func MigrateAttendanceActivityNullable(db *gorm.DB) error {
 	}
 	return nil
 }
+
+// BackfillWeightedHours 为加权时长上线前的考勤补齐加权时长，未分类考勤权重为 1
+func BackfillWeightedHours(db *gorm.DB) error {
+	return db.Model(&models.Attendance{}).
+		Where("type = ? AND weighted_hours = 0 AND duration_hours > 0", "").
+		Update("weighted_hours", gorm.Expr("duration_hours")).Error
+}
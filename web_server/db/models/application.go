@@ -0,0 +1,21 @@
+package models
+
+// ApplicationQuestion 社团入社申请问题
+type ApplicationQuestion struct {
+	BaseModel
+	ClubID   uint     `gorm:"index" json:"club_id"`
+	Title    string   `gorm:"size:255;not null" json:"title"`
+	Kind     string   `gorm:"size:16;default:'text'" json:"kind"` // text, choice
+	Options  []string `gorm:"serializer:json;type:text" json:"options"`
+	Required bool     `json:"required"`
+	Sort     int      `json:"sort"`
+}
+
+// ApplicationAnswer 入社申请的回答，保存提交时的问题标题，问题修改或删除后仍可查看
+type ApplicationAnswer struct {
+	BaseModel
+	MembershipID uint   `gorm:"index" json:"membership_id"`
+	QuestionID   uint   `json:"question_id"`
+	Question     string `gorm:"size:255" json:"question"`
+	Answer       string `gorm:"type:text" json:"answer"`
+}
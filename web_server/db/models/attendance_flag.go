@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AttendanceFlag 考勤异常标记，进入负责人/管理员审核队列
+type AttendanceFlag struct {
+	BaseModel
+	AttendanceID uint       `gorm:"uniqueIndex:ux_attendance_kind" json:"attendance_id"`
+	Attendance   Attendance `json:"attendance"`
+	ClubID       uint       `gorm:"index" json:"club_id"`
+	Club         Club       `json:"club"`
+	UserID       uint       `gorm:"index" json:"user_id"`
+	User         User       `json:"user"`
+	Kind         string     `gorm:"size:32;uniqueIndex:ux_attendance_kind" json:"kind"` // overlap, long_session, burst, outside_window
+	Detail       string     `gorm:"size:255" json:"detail"`
+	Status       string     `gorm:"size:16;default:'pending';index" json:"status"` // pending, accepted, voided
+	ReviewerID   uint       `json:"reviewer_id"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// AttendancePolicy 社团考勤计时规则，每次修改追加一个新版本
+type AttendancePolicy struct {
+	BaseModel
+	ClubID          uint   `gorm:"uniqueIndex:ux_club_version" json:"club_id"`
+	Version         int    `gorm:"uniqueIndex:ux_club_version" json:"version"`
+	MinMinutes      int    `json:"min_minutes"`                  // 不足该时长的记录不计入
+	MaxMinutes      int    `json:"max_minutes"`                  // 单次最多计入时长，0 表示不限
+	RoundingMinutes int    `json:"rounding_minutes"`             // 取整粒度（分钟），0 表示按0.01小时计
+	RoundingMode    string `gorm:"size:16" json:"rounding_mode"` // down, nearest, up
+	SigninStart     string `gorm:"size:5" json:"signin_start"`   // 允许签到开始时间 HH:MM，空表示不限
+	SigninEnd       string `gorm:"size:5" json:"signin_end"`     // 允许签到结束时间 HH:MM，空表示不限
+	CreatedBy       uint   `json:"created_by"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// AttendanceSyncEvent 离线签到补传事件，按用户与客户端幂等键去重
+type AttendanceSyncEvent struct {
+	BaseModel
+	UserID       uint      `gorm:"uniqueIndex:ux_user_key" json:"user_id"`
+	Key          string    `gorm:"size:64;uniqueIndex:ux_user_key" json:"key"`
+	DeviceID     uint      `gorm:"index" json:"device_id"`
+	Action       string    `gorm:"size:16" json:"action"` // signin, signout
+	ClubID       uint      `json:"club_id"`
+	ActivityID   *uint     `json:"activity_id"`
+	ClientTime   time.Time `json:"client_time"`
+	Status       string    `gorm:"size:16" json:"status"` // ok, rejected
+	Message      string    `gorm:"size:255" json:"message"`
+	AttendanceID uint      `json:"attendance_id"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type ClubCategory struct {
 	BaseModel
 	Name string `gorm:"size:64;uniqueIndex;not null" json:"name"`
@@ -7,21 +9,41 @@ type ClubCategory struct {
 
 type Club struct {
 	BaseModel
-	Name       string       `gorm:"size:64;uniqueIndex;not null" json:"name"`
-	Logo       string       `gorm:"size:255" json:"logo"`
-	Intro      string       `gorm:"type:text" json:"intro"`
-	Contact    string       `gorm:"size:64" json:"contact"`
-	CategoryID uint         `gorm:"index" json:"category_id"`
-	Category   ClubCategory `json:"category"`
-	Status     string       `gorm:"size:32;default:'pending';index" json:"status"` // pending, approved, rejected
+	Name                    string       `gorm:"size:64;uniqueIndex;not null" json:"name"`
+	Logo                    string       `gorm:"size:255" json:"logo"`
+	Intro                   string       `gorm:"type:text" json:"intro"`
+	Contact                 string       `gorm:"size:64" json:"contact"`
+	CategoryID              uint         `gorm:"index" json:"category_id"`
+	Category                ClubCategory `json:"category"`
+	Status                  string       `gorm:"size:32;default:'pending';index" json:"status"` // pending, approved, rejected
+	RequireCampaign         bool         `json:"require_campaign"`                              // 为 true 时仅在招新活动期间接受入社申请
+	MembershipMonths        int          `gorm:"default:0" json:"membership_months"`            // 成员资格有效期（月），0 表示长期有效（默认）
+	RenewalWindowDays       int          `gorm:"default:30" json:"renewal_window_days"`         // 到期前多少天内可续期
+	RenewalRequiresApproval bool         `json:"renewal_requires_approval"`
+	HandoverRequiresAdmin   bool         `json:"handover_requires_admin"` // 社长交接需管理员确认
 }
 
 type Membership struct {
 	BaseModel
-	UserID uint   `gorm:"index;uniqueIndex:ux_user_club" json:"user_id"`
-	ClubID uint   `gorm:"index;uniqueIndex:ux_user_club" json:"club_id"`
-	Status string `gorm:"size:16" json:"status"`
-	Role   string `gorm:"size:32;index" json:"role"`
-	User   User   `json:"user"`
-	Club   Club   `json:"club"`
+	UserID             uint                `gorm:"index;uniqueIndex:ux_user_club" json:"user_id"`
+	ClubID             uint                `gorm:"index;uniqueIndex:ux_user_club" json:"club_id"`
+	Status             string              `gorm:"size:16" json:"status"` // pending, approved, rejected, quit, kicked, expired
+	Role               string              `gorm:"size:32;index" json:"role"`
+	Attachment         string              `gorm:"size:255" json:"attachment"` // 申请附件，如作品集图片
+	CampaignID         *uint               `gorm:"index" json:"campaign_id"`   // 申请时所在的招新活动
+	Answers            []ApplicationAnswer `json:"answers,omitempty"`
+	Interview          *Interview          `json:"interview,omitempty"`
+	Tags               []MembershipTag     `json:"tags,omitempty"`                // 仅负责人接口返回
+	ExpiresAt          *time.Time          `gorm:"index" json:"expires_at"`       // 负责人不受到期限制
+	RenewalStatus      string              `gorm:"size:16" json:"renewal_status"` // pending 表示续期待负责人审核
+	RenewalRequestedAt *time.Time          `json:"renewal_requested_at"`
+	RejectReason       string              `gorm:"size:255" json:"reject_reason"` // 驳回原因，申请人可见
+	StatusChangedAt    *time.Time          `json:"status_changed_at"`             // 最近一次状态变更时间，用于计算再次申请冷却期
+	StatusChangedBy    uint                `json:"status_changed_by"`
+	JoinedAt           *time.Time          `json:"joined_at"`                                   // 最近一次成为正式成员的时间
+	LeftAt             *time.Time          `json:"left_at"`                                     // 最近一次退社、被移出或到期的时间
+	RolesHeld          []string            `gorm:"serializer:json;type:text" json:"roles_held"` // 在社期间担任过的角色编码
+	AlumniVisible      bool                `json:"alumni_visible"`                              // 本人同意出现在社团校友名录中
+	User               User                `json:"user"`
+	Club               Club                `json:"club"`
 }
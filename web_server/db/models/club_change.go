@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ClubChangeRequest 社团名称、分类等敏感信息的变更申请，管理员通过后才写入社团
+type ClubChangeRequest struct {
+	BaseModel
+	ClubID        uint       `gorm:"index" json:"club_id"`
+	Club          Club       `json:"club"`
+	RequestedBy   uint       `gorm:"index" json:"requested_by"`
+	Requester     User       `gorm:"foreignKey:RequestedBy" json:"requester"`
+	OldName       string     `gorm:"size:64" json:"old_name"`
+	NewName       string     `gorm:"size:64" json:"new_name"` // 为空表示不修改
+	OldCategoryID uint       `json:"old_category_id"`
+	NewCategoryID uint       `json:"new_category_id"`             // 为 0 表示不修改
+	Note          string     `gorm:"size:255" json:"note"`        // 申请说明
+	Status        string     `gorm:"size:16;index" json:"status"` // pending, approved, rejected, cancelled
+	ReviewedBy    uint       `json:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewNote    string     `gorm:"size:255" json:"review_note"` // 驳回原因，申请人可见
+}
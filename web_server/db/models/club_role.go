@@ -0,0 +1,11 @@
+package models
+
+// ClubRole 社团自定义角色，如宣传部长、财务；Membership.Role 保存其 Code
+type ClubRole struct {
+	BaseModel
+	ClubID      uint     `gorm:"index;uniqueIndex:ux_club_role_code" json:"club_id"`
+	Code        string   `gorm:"size:32;uniqueIndex:ux_club_role_code" json:"code"`
+	Name        string   `gorm:"size:64" json:"name"`
+	Permissions []string `gorm:"serializer:json;type:text" json:"permissions"`
+	BuiltIn     bool     `gorm:"-" json:"built_in"` // 内置角色不入库
+}
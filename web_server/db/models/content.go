@@ -4,11 +4,12 @@ import "time"
 
 type Announcement struct {
 	BaseModel
-	Title   string `gorm:"size:128;not null" json:"title"`
-	Content string `gorm:"type:text" json:"content"`
-	Scope   string `gorm:"size:16" json:"scope"`
-	ClubID  uint   `gorm:"index" json:"club_id"`
-	Club    Club   `json:"club"`
+	Title        string `gorm:"size:128;not null" json:"title"`
+	Content      string `gorm:"type:text" json:"content"`
+	Scope        string `gorm:"size:16" json:"scope"`
+	ClubID       uint   `gorm:"index" json:"club_id"`
+	Club         Club   `json:"club"`
+	DepartmentID *uint  `gorm:"index" json:"department_id"` // 为空表示面向全社团
 }
 
 type Activity struct {
@@ -25,6 +26,7 @@ type Activity struct {
 	EndAt           *time.Time `json:"end_at"`
 	MaxParticipants int        `json:"max_participants"`
 	PublishAt       *time.Time `json:"publish_at"`
+	Type            string     `gorm:"size:32" json:"type"` // 默认考勤类型编码
 }
 
 type Attendance struct {
@@ -34,11 +36,17 @@ type Attendance struct {
 	ActivityID      *uint      `gorm:"index" json:"activity_id"`
 	ClubID          uint       `gorm:"index" json:"club_id"`
 	Club            Club       `json:"club"`
-	Type            string     `gorm:"size:16" json:"type"`
+	Type            string     `gorm:"size:32;index" json:"type"`
+	Ledger          string     `gorm:"size:32" json:"ledger"`
 	SigninAt        *time.Time `json:"signin_at"`
 	SignoutAt       *time.Time `json:"signout_at"`
 	DurationMinutes int        `json:"duration_minutes"`
 	DurationHours   float64    `gorm:"type:decimal(8,2)" json:"duration_hours"`
+	WeightedHours   float64    `gorm:"type:decimal(8,2)" json:"weighted_hours"`
+	PolicyVersion   int        `json:"policy_version"`
+	VoidedAt        *time.Time `gorm:"index" json:"voided_at"`
+	VoidedBy        uint       `json:"voided_by"`
+	VoidReason      string     `gorm:"size:255" json:"void_reason"`
 	Activity        Activity   `json:"activity"`
 }
 
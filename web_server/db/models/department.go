@@ -0,0 +1,20 @@
+package models
+
+// Department 社团内部门，如志愿者协会的外联部、宣传部
+type Department struct {
+	BaseModel
+	ClubID uint   `gorm:"index;uniqueIndex:ux_club_dept" json:"club_id"`
+	Name   string `gorm:"size:64;uniqueIndex:ux_club_dept" json:"name"`
+	Intro  string `gorm:"size:255" json:"intro"`
+	Sort   int    `json:"sort"`
+}
+
+// DepartmentMember 部门成员，一名成员可加入多个部门
+type DepartmentMember struct {
+	BaseModel
+	DepartmentID uint `gorm:"uniqueIndex:ux_dept_user" json:"department_id"`
+	UserID       uint `gorm:"uniqueIndex:ux_dept_user;index" json:"user_id"`
+	User         User `json:"user"`
+	ClubID       uint `gorm:"index" json:"club_id"`
+	IsHead       bool `json:"is_head"` // 部门负责人，可管理本部门的考勤与公告
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Device 小程序设备，离线签到补传时用其密钥校验事件签名
+type Device struct {
+	BaseModel
+	UserID     uint       `gorm:"index" json:"user_id"`
+	Name       string     `gorm:"size:64" json:"name"`
+	Secret     string     `gorm:"size:64" json:"-"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
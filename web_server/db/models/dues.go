@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// DuesSchedule 社团社费标准，每个社团一条
+type DuesSchedule struct {
+	BaseModel
+	ClubID            uint      `gorm:"uniqueIndex" json:"club_id"`
+	Amount            float64   `gorm:"type:decimal(10,2)" json:"amount"`
+	PeriodMonths      int       `json:"period_months"`               // 缴费周期（月），12 表示按年
+	StartDate         time.Time `gorm:"type:date" json:"start_date"` // 第一个缴费周期的开始日期
+	BlockRegistration bool      `json:"block_registration"`          // 未缴清社费时禁止报名活动
+}
+
+// DuesPayment 社费缴纳记录，仅记录线下收款结果
+type DuesPayment struct {
+	BaseModel
+	MembershipID   uint      `gorm:"index" json:"membership_id"`
+	ClubID         uint      `gorm:"index;uniqueIndex:ux_club_receipt" json:"club_id"`
+	UserID         uint      `gorm:"index" json:"user_id"`
+	User           User      `json:"user"`
+	Amount         float64   `gorm:"type:decimal(10,2)" json:"amount"`
+	Method         string    `gorm:"size:16" json:"method"` // cash, wechat, alipay, transfer, other
+	ReceiptNo      string    `gorm:"size:64;uniqueIndex:ux_club_receipt" json:"receipt_no"`
+	PeriodStart    time.Time `gorm:"type:date;index" json:"period_start"`
+	PeriodEnd      time.Time `gorm:"type:date" json:"period_end"`
+	PaidAt         time.Time `json:"paid_at"`
+	RecordedBy     uint      `json:"recorded_by"`
+	RecordedByName string    `gorm:"size:64" json:"recorded_by_name"`
+	Note           string    `gorm:"size:255" json:"note"`
+}
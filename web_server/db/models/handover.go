@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// LeaderHandover 社长交接：现任社长提名继任者，继任者接受后（按需经管理员确认）完成交接
+type LeaderHandover struct {
+	BaseModel
+	ClubID       uint       `gorm:"index" json:"club_id"`
+	Club         Club       `json:"club"`
+	FromUserID   uint       `gorm:"index" json:"from_user_id"`
+	FromUser     User       `json:"from_user"`
+	ToUserID     uint       `gorm:"index" json:"to_user_id"`
+	ToUser       User       `json:"to_user"`
+	OutgoingRole string     `gorm:"size:16" json:"outgoing_role"` // 原社长交接后的身份：member, alumni
+	Note         string     `gorm:"size:255" json:"note"`
+	RequireAdmin bool       `json:"require_admin"`               // 发起时社团是否要求管理员确认
+	Status       string     `gorm:"size:16;index" json:"status"` // pending, accepted, completed, declined, cancelled, rejected
+	AcceptedAt   *time.Time `json:"accepted_at"`
+	ConfirmedBy  uint       `json:"confirmed_by"` // 确认或驳回的管理员
+	CompletedAt  *time.Time `json:"completed_at"`
+}
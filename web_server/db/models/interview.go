@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// InterviewSlot 招新面试时段
+type InterviewSlot struct {
+	BaseModel
+	CampaignID uint      `gorm:"index" json:"campaign_id"`
+	ClubID     uint      `gorm:"index" json:"club_id"`
+	StartAt    time.Time `gorm:"index" json:"start_at"`
+	EndAt      time.Time `json:"end_at"`
+	Place      string    `gorm:"size:128" json:"place"`
+	Capacity   int       `json:"capacity"`
+	Booked     int       `json:"booked"`
+}
+
+// Interview 申请人的面试预约与结果，每个入社申请仅一条
+type Interview struct {
+	BaseModel
+	MembershipID    uint          `gorm:"uniqueIndex" json:"membership_id"`
+	SlotID          uint          `gorm:"index" json:"slot_id"`
+	Slot            InterviewSlot `json:"slot"`
+	UserID          uint          `gorm:"index" json:"user_id"`
+	User            User          `json:"user"`
+	ClubID          uint          `gorm:"index" json:"club_id"`
+	Score           *int          `json:"score"` // 0-100，未评分为空
+	Notes           string        `gorm:"type:text" json:"notes"`
+	InterviewerID   uint          `json:"interviewer_id"`
+	InterviewerName string        `gorm:"size:64" json:"interviewer_name"`
+	ScoredAt        *time.Time    `json:"scored_at"`
+}
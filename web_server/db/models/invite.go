@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// InviteCode 社团邀请码，可生成二维码供学生扫码加入
+type InviteCode struct {
+	BaseModel
+	ClubID      uint       `gorm:"index" json:"club_id"`
+	Code        string     `gorm:"size:16;uniqueIndex" json:"code"`
+	Role        string     `gorm:"size:32" json:"role"` // 加入后的角色：member, advisor
+	AutoApprove bool       `json:"auto_approve"`        // false 时加入后为待审核
+	MaxUses     int        `json:"max_uses"`            // 0 表示不限次数
+	Uses        int        `json:"uses"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedBy   uint       `json:"created_by"`
+}
+
+// InviteRedemption 邀请码使用记录
+type InviteRedemption struct {
+	BaseModel
+	CodeID       uint   `gorm:"uniqueIndex:ux_code_user" json:"code_id"`
+	UserID       uint   `gorm:"uniqueIndex:ux_code_user" json:"user_id"`
+	User         User   `json:"user"`
+	MembershipID uint   `json:"membership_id"`
+	Status       string `gorm:"size:16" json:"status"` // 加入后的成员状态：approved, pending
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// LeaveRequest 请假申请，针对某个活动或某天的社团例会
+type LeaveRequest struct {
+	BaseModel
+	UserID     uint       `gorm:"index" json:"user_id"`
+	User       User       `json:"user"`
+	ClubID     uint       `gorm:"index" json:"club_id"`
+	Club       Club       `json:"club"`
+	ActivityID *uint      `gorm:"index" json:"activity_id"`
+	Activity   Activity   `json:"activity"`
+	LeaveDate  time.Time  `gorm:"type:date;index" json:"leave_date"` // 例会日期；活动请假时为活动开始日期
+	Type       string     `gorm:"size:16" json:"type"`               // sick, personal, official, other
+	Reason     string     `gorm:"size:500" json:"reason"`
+	Attachment string     `gorm:"size:255" json:"attachment"`
+	Status     string     `gorm:"size:16;default:'pending';index" json:"status"` // pending, approved, rejected, cancelled
+	ReviewerID uint       `json:"reviewer_id"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	ReviewNote string     `gorm:"size:255" json:"review_note"`
+}
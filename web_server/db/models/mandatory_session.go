@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// MandatorySession 必到场次，可关联活动或为社团自定义时段，结束后为名单中未签到者生成缺勤记录
+type MandatorySession struct {
+	BaseModel
+	ClubID      uint                   `gorm:"index" json:"club_id"`
+	ActivityID  *uint                  `gorm:"index" json:"activity_id"`
+	Title       string                 `gorm:"size:128" json:"title"`
+	StartAt     time.Time              `json:"start_at"`
+	EndAt       time.Time              `gorm:"index" json:"end_at"`
+	RosterMode  string                 `gorm:"size:16" json:"roster_mode"` // all, role, list
+	RosterRole  string                 `gorm:"size:32" json:"roster_role"`
+	Roster      []MandatoryRosterEntry `gorm:"foreignKey:SessionID" json:"roster,omitempty"`
+	ProcessedAt *time.Time             `gorm:"index" json:"processed_at"`
+	CreatedBy   uint                   `json:"created_by"`
+}
+
+// MandatoryRosterEntry 指定名单模式下的应到成员
+type MandatoryRosterEntry struct {
+	BaseModel
+	SessionID uint `gorm:"uniqueIndex:ux_session_user" json:"session_id"`
+	UserID    uint `gorm:"uniqueIndex:ux_session_user" json:"user_id"`
+}
+
+// Absence 缺勤记录
+type Absence struct {
+	BaseModel
+	SessionID  uint             `gorm:"uniqueIndex:ux_session_user" json:"session_id"`
+	Session    MandatorySession `json:"session"`
+	ClubID     uint             `gorm:"index" json:"club_id"`
+	UserID     uint             `gorm:"uniqueIndex:ux_session_user;index" json:"user_id"`
+	User       User             `json:"user"`
+	ActivityID *uint            `json:"activity_id"`
+	Status     string           `gorm:"size:16;default:'absent';index" json:"status"` // absent, excused（已批准请假）
+}
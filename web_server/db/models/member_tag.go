@@ -0,0 +1,28 @@
+package models
+
+// MemberTag 社团内成员标签，如“擅长摄影”“需要关注”，仅负责人可见
+type MemberTag struct {
+	BaseModel
+	ClubID uint   `gorm:"index;uniqueIndex:ux_club_tag" json:"club_id"`
+	Name   string `gorm:"size:32;uniqueIndex:ux_club_tag" json:"name"`
+	Color  string `gorm:"size:16" json:"color"`
+}
+
+// MembershipTag 成员与标签的关联
+type MembershipTag struct {
+	BaseModel
+	MembershipID uint      `gorm:"uniqueIndex:ux_membership_tag" json:"membership_id"`
+	TagID        uint      `gorm:"uniqueIndex:ux_membership_tag;index" json:"tag_id"`
+	Tag          MemberTag `json:"tag"`
+	ClubID       uint      `gorm:"index" json:"club_id"`
+}
+
+// MemberNote 负责人对成员的备注，仅负责人可见
+type MemberNote struct {
+	BaseModel
+	MembershipID uint   `gorm:"index" json:"membership_id"`
+	ClubID       uint   `gorm:"index" json:"club_id"`
+	AuthorID     uint   `json:"author_id"`
+	AuthorName   string `gorm:"size:64" json:"author_name"`
+	Content      string `gorm:"type:text" json:"content"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// MembershipEvent 成员关系变更记录，只追加不修改：状态流转与角色调整各记一条
+type MembershipEvent struct {
+	BaseModel
+	MembershipID uint   `gorm:"index" json:"membership_id"`
+	ClubID       uint   `gorm:"index" json:"club_id"`
+	UserID       uint   `gorm:"index" json:"user_id"`
+	ActorID      uint   `json:"actor_id"`                    // 0 表示系统任务，如到期处理
+	Actor        User   `gorm:"foreignKey:ActorID" json:"-"` // 接口只返回操作人 ID 与姓名
+	FromStatus   string `gorm:"size:16" json:"from_status"`  // 为空表示新建成员记录
+	ToStatus     string `gorm:"size:16" json:"to_status"`
+	FromRole     string `gorm:"size:32" json:"from_role"`
+	ToRole       string `gorm:"size:32" json:"to_role"`
+	Reason       string `gorm:"size:255" json:"reason"`
+}
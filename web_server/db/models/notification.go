@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Notification 站内通知，RefKey 用于避免同一事件重复通知
+type Notification struct {
+	BaseModel
+	UserID  uint       `gorm:"index;uniqueIndex:ux_user_ref" json:"user_id"`
+	ClubID  uint       `gorm:"index" json:"club_id"`
+	Kind    string     `gorm:"size:32" json:"kind"` // renewal_reminder, membership_expired, leader_handover
+	Title   string     `gorm:"size:128" json:"title"`
+	Content string     `gorm:"size:500" json:"content"`
+	RefKey  string     `gorm:"size:64;uniqueIndex:ux_user_ref" json:"-"`
+	ReadAt  *time.Time `json:"read_at"`
+}
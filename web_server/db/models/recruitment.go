@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RecruitmentCampaign 社团招新活动，同一社团的招新时间段不重叠
+type RecruitmentCampaign struct {
+	BaseModel
+	ClubID        uint           `gorm:"index" json:"club_id"`
+	Club          Club           `json:"club"`
+	Title         string         `gorm:"size:128" json:"title"`
+	StartAt       time.Time      `gorm:"index" json:"start_at"`
+	EndAt         time.Time      `gorm:"index" json:"end_at"`
+	Quota         int            `json:"quota"`                                           // 录取名额，0 表示不限
+	CollegeQuotas map[string]int `gorm:"serializer:json;type:text" json:"college_quotas"` // 学院 -> 名额，未列出的学院只受总名额限制
+	CreatedBy     uint           `json:"created_by"`
+}
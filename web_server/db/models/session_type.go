@@ -0,0 +1,11 @@
+package models
+
+// SessionType 社团自定义的考勤类型，如例会、训练、演出、志愿服务
+type SessionType struct {
+	BaseModel
+	ClubID uint    `gorm:"uniqueIndex:ux_club_type_code" json:"club_id"`
+	Code   string  `gorm:"size:32;uniqueIndex:ux_club_type_code;not null" json:"code"`
+	Name   string  `gorm:"size:64;not null" json:"name"`
+	Weight float64 `gorm:"type:decimal(6,2);default:1" json:"weight"` // 计入时长的权重
+	Ledger string  `gorm:"size:32" json:"ledger"`                     // 计入台账: volunteer(志愿时长), second_classroom(第二课堂学分)，空表示不计入
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Term 学期，由管理员维护，用于限定列表与统计的时间范围
+type Term struct {
+	BaseModel
+	Name      string    `gorm:"size:64;uniqueIndex;not null" json:"name"`
+	StartDate time.Time `gorm:"type:date;index" json:"start_date"`
+	EndDate   time.Time `gorm:"type:date;index" json:"end_date"`
+}
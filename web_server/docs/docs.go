@@ -15,39 +15,74 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/memberships/{id}/role": {
-            "post": {
+        "/admin/attendance": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "管理员"
                 ],
-                "summary": "更新成员社团内角色",
+                "summary": "获取管理考勤列表（管理员/负责人）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "成员关系ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "角色值",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/controllers.UpdateRoleReq"
-                        }
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "社团名称",
+                        "name": "club_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "成员名字",
+                        "name": "user_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学号",
+                        "name": "student_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "日期(YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "考勤类型编码",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID，部门负责人可查看本部门",
+                        "name": "department_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -60,24 +95,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/admin/attendance/anomalies": {
             "get": {
-                "tags": [
-                    "公共"
-                ],
-                "summary": "健康检查",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response.Body"
-                        }
-                    }
-                }
-            }
-        },
-        "/leader/attendance/{id}": {
-            "delete": {
                 "security": [
                     {
                         "Bearer": []
@@ -89,14 +108,37 @@ const docTemplate = `{
                 "tags": [
                     "考勤"
                 ],
-                "summary": "删除异常考勤记录（负责人）",
+                "summary": "考勤异常审核队列（负责人/管理员）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "考勤记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "社团ID",
+                        "name": "club_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态: pending/accepted/voided，默认pending",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "类型: overlap/long_session/burst/outside_window",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -109,7 +151,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/attendance": {
+        "/admin/attendance/stats": {
             "get": {
                 "security": [
                     {
@@ -122,31 +164,60 @@ const docTemplate = `{
                 "tags": [
                     "考勤"
                 ],
-                "summary": "社团考勤检索（负责人）",
+                "summary": "考勤统计（按成员/社团/月/周汇总）",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "社团ID",
-                        "name": "clubId",
-                        "in": "path",
-                        "required": true
+                        "name": "club_id",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
                         "description": "用户ID",
-                        "name": "userId",
+                        "name": "user_id",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
+                        "type": "string",
+                        "description": "开始日期(YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期(YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "来源: activity(活动签到)/club(社团签到)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "考勤类型编码",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "附加分组: term(按学期汇总)",
+                        "name": "group_by",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
+                        "description": "部门ID，部门负责人可查看本部门",
+                        "name": "department_id",
                         "in": "query"
                     }
                 ],
@@ -160,7 +231,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/members/users": {
+        "/admin/club-changes": {
             "get": {
                 "security": [
                     {
@@ -171,35 +242,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "成员"
+                    "管理员"
                 ],
-                "summary": "社团成员列表（负责人）",
+                "summary": "社团变更申请列表（管理员）",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "角色: member/leader/advisor",
-                        "name": "role",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "状态: pending/approved/rejected/quit",
+                        "description": "状态(pending/approved/rejected/cancelled)",
                         "name": "status",
                         "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "关键词：姓名/学号/手机号",
-                        "name": "keyword",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "description": "页码",
@@ -223,45 +275,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/members/{userId}/role": {
+        "/admin/club-changes/{id}/approve": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "负责人"
+                    "管理员"
                 ],
-                "summary": "负责人设定成员社团内角色",
+                "summary": "通过社团变更申请（管理员）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
+                        "description": "申请ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/club-changes/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "管理员"
+                ],
+                "summary": "驳回社团变更申请（管理员）",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "用户ID",
-                        "name": "userId",
+                        "description": "申请ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "角色值",
+                        "description": "驳回原因",
                         "name": "payload",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.setRoleReq"
+                            "$ref": "#/definitions/controllers.ReviewClubChangeReq"
                         }
                     }
                 ],
@@ -275,7 +352,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/memberships": {
+        "/admin/clubs/audit": {
             "get": {
                 "security": [
                     {
@@ -286,27 +363,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "成员"
+                    "管理员"
                 ],
-                "summary": "待审批入会列表（负责人）",
+                "summary": "获取社团审批列表",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
+                        "type": "string",
+                        "description": "状态(pending/approved/rejected)",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
@@ -320,23 +384,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/memberships/{id}/approve": {
-            "post": {
+        "/admin/clubs/{clubId}": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "成员"
+                    "管理员"
                 ],
-                "summary": "审批通过成员（负责人）",
+                "summary": "解散社团",
                 "parameters": [
                     {
                         "type": "integer",
@@ -344,13 +405,6 @@ const docTemplate = `{
                         "name": "clubId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "成员关系ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -363,8 +417,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/memberships/{id}/reject": {
-            "post": {
+        "/admin/clubs/{clubId}/handover-settings": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
@@ -377,9 +431,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "成员"
+                    "管理员"
                 ],
-                "summary": "审批驳回成员（负责人）",
+                "summary": "设置社长交接是否需管理员确认（管理员）",
                 "parameters": [
                     {
                         "type": "integer",
@@ -389,11 +443,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "成员关系ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "交接设置",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.HandoverSettingsReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -406,39 +462,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/clubs/{clubId}/users": {
-            "get": {
+        "/admin/clubs/{id}/audit": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "负责人"
+                    "管理员"
                 ],
-                "summary": "获取社团负责人列表",
+                "summary": "审核社团",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "社团ID",
-                        "name": "clubId",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "审核结果",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AuditClubReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -451,7 +507,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/leader/users/{userId}/clubs": {
+        "/admin/handovers": {
             "get": {
                 "security": [
                     {
@@ -462,16 +518,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "负责人"
+                    "管理员"
                 ],
-                "summary": "获取用户负责的社团列表",
+                "summary": "社长交接列表（管理员）",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "状态：accepted 为待确认",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -496,7 +551,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/member/activities/{activityId}/signin": {
+        "/admin/handovers/{id}/confirm": {
             "post": {
                 "security": [
                     {
@@ -507,14 +562,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "考勤"
+                    "管理员"
                 ],
-                "summary": "会员签到",
+                "summary": "确认社长交接（管理员）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "活动ID",
-                        "name": "activityId",
+                        "description": "交接ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -529,7 +584,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/member/activities/{activityId}/signout": {
+        "/admin/handovers/{id}/reject": {
             "post": {
                 "security": [
                     {
@@ -540,14 +595,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "考勤"
+                    "管理员"
                 ],
-                "summary": "会员签退",
+                "summary": "驳回社长交接（管理员）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "活动ID",
-                        "name": "activityId",
+                        "description": "交接ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -562,39 +617,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/member/attendance/my": {
-            "get": {
+        "/admin/memberships/{id}/role": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "不能任命或撤换社长，社长变更请发起社长交接",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "考勤"
+                    "管理员"
                 ],
-                "summary": "我的考勤记录（按社团）",
+                "summary": "更新成员社团内角色",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
-                        "in": "query",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "角色值",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateRoleReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -607,7 +663,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/activities": {
+        "/admin/terms": {
             "get": {
                 "produces": [
                     "application/json"
@@ -615,45 +671,7 @@ const docTemplate = `{
                 "tags": [
                     "公共"
                 ],
-                "summary": "活动列表（公开）",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键词",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "开始时间",
-                        "name": "start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束时间",
-                        "name": "end",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
-                    }
-                ],
+                "summary": "学期列表（公开）",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -662,41 +680,32 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/public/announcements": {
-            "get": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "公共"
+                    "管理员"
                 ],
-                "summary": "公告列表（公开）",
+                "summary": "创建学期",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "关键词",
-                        "name": "keyword",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "学期信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TermReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -709,15 +718,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/categories": {
-            "get": {
+        "/admin/terms/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "公共"
+                    "管理员"
+                ],
+                "summary": "修改学期",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "学期ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "学期信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TermReq"
+                        }
+                    }
                 ],
-                "summary": "社团类别列表（公开）",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -726,29 +761,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/public/clubs": {
-            "get": {
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "公共"
+                    "管理员"
                 ],
-                "summary": "社团列表（含类别、代表活动、负责人联系方式）",
+                "summary": "删除学期",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "学期ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -761,24 +794,12 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/clubs/{clubId}": {
+        "/health": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
                     "公共"
                 ],
-                "summary": "社团详情（公开）",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "健康检查",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -789,8 +810,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/login": {
-            "post": {
+        "/leader/activities/{activityId}/type": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -798,17 +824,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "公共"
+                    "考勤"
                 ],
-                "summary": "登录",
+                "summary": "设置活动默认考勤类型（负责人）",
                 "parameters": [
                     {
-                        "description": "登录参数",
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "考勤类型",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/controllers.LoginReq"
+                            "$ref": "#/definitions/controllers.ActivityTypeReq"
                         }
                     }
                 ],
@@ -822,28 +855,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/public/register": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/leader/attendance/anomalies": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "公共"
+                    "考勤"
                 ],
-                "summary": "注册",
+                "summary": "考勤异常审核队列（负责人/管理员）",
                 "parameters": [
                     {
-                        "description": "注册参数",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "club_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态: pending/accepted/voided，默认pending",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "类型: overlap/long_session/burst/outside_window",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.RegisterReq"
+                            "$ref": "#/definitions/response.Body"
                         }
                     }
+                }
+            }
+        },
+        "/leader/attendance/anomalies/scan": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "扫描考勤异常（负责人/管理员）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "club_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "扫描最近天数，默认30",
+                        "name": "days",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
@@ -855,7 +949,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/student/clubs/{clubId}/apply": {
+        "/leader/attendance/anomalies/{id}/accept": {
             "post": {
                 "security": [
                     {
@@ -866,14 +960,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "学生"
+                    "考勤"
                 ],
-                "summary": "申请加入社团",
+                "summary": "确认考勤异常记录有效（负责人/管理员）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
+                        "description": "异常标记ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -888,7 +982,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/student/clubs/{clubId}/exit": {
+        "/leader/attendance/anomalies/{id}/void": {
             "post": {
                 "security": [
                     {
@@ -899,14 +993,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "学生"
+                    "考勤"
                 ],
-                "summary": "退出社团",
+                "summary": "作废异常考勤记录（负责人/管理员）",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "社团ID",
-                        "name": "clubId",
+                        "description": "异常标记ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -921,7 +1015,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/student/memberships/my": {
+        "/leader/attendance/list": {
             "get": {
                 "security": [
                     {
@@ -932,26 +1026,142 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "学生"
+                    "管理员"
                 ],
-                "summary": "我的社团关系",
+                "summary": "获取管理考勤列表（管理员/负责人）",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "size",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "状态: pending/approved/rejected/quit",
-                        "name": "status",
+                        "description": "社团名称",
+                        "name": "club_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "成员名字",
+                        "name": "user_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学号",
+                        "name": "student_no",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "日期(YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "考勤类型编码",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "页码",
-                        "name": "page",
+                        "description": "部门ID，部门负责人可查看本部门",
+                        "name": "department_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/attendance/stats": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "考勤统计（按成员/社团/月/周汇总）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "club_id",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期(YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期(YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "来源: activity(活动签到)/club(社团签到)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "term_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "考勤类型编码",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "附加分组: term(按学期汇总)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID，部门负责人可查看本部门",
+                        "name": "department_id",
                         "in": "query"
                     }
                 ],
@@ -965,30 +1175,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/upload/image": {
-            "post": {
+        "/leader/attendance/{id}": {
+            "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "上传"
+                    "考勤"
                 ],
-                "summary": "上传图片",
+                "summary": "作废考勤记录（负责人）",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "图片文件",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "考勤记录ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "作废原因（也可通过请求体传递）",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "description": "作废原因",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DeleteAttendanceReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -1000,21 +1224,6616 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.LoginReq": {
-            "type": "object",
-            "required": [
-                "account",
-                "password"
-            ],
-            "properties": {
-                "account": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+        },
+        "/leader/attendance/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "恢复已作废考勤记录（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "考勤记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/attendance/{id}/signout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "强制签退（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "考勤记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/absences/summary": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "缺勤次数达到阈值的成员 flagged 为 true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "成员缺勤统计（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "termId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "仅返回被标记的成员",
+                        "name": "flagged",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/announcements": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "获取社团公告列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "创建社团公告",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "公告内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AnnouncementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/announcements/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "更新社团公告",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "公告ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "公告内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AnnouncementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "删除社团公告",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "公告ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/attendance": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "社团考勤检索（负责人/部门负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID，部门负责人仅可查看自己负责的部门",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "termId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "考勤类型编码",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "list 为考勤记录，leaves 为同条件下已批准的请假",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/attendance/policy": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "获取社团考勤规则（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "修改社团考勤规则（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "考勤规则",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AttendancePolicyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/attendance/voided": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "已作废考勤记录（负责人/部门负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/campaigns": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "招新活动列表（负责人，含实时申请与名额统计）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "创建招新活动（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "招新活动",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CampaignReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/campaigns/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "修改招新活动（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "招新活动ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "招新活动",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.CampaignReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "删除招新活动（负责人，仅限尚无申请的活动）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "招新活动ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/campaigns/{id}/slots": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "招新面试时段列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "招新活动ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "发布面试时段（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "招新活动ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "面试时段",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.InterviewSlotReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/change-requests": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "社团变更申请记录（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/change-requests/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "撤回社团变更申请（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "申请ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/departments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "部门列表（负责人/部门负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "创建部门（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "部门信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DepartmentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/departments/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "更新部门（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "部门信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DepartmentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "同时移除部门成员；部门仍有公告时不能删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "删除部门（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/departments/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "部门成员列表（负责人/部门负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "成员已在部门中时更新其是否为负责人",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "添加部门成员或设置部门负责人（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员与负责人标记",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DepartmentMemberReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/departments/{id}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "部门"
+                ],
+                "summary": "移出部门成员（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/dues/outstanding": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "本期欠费成员（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/dues/payments": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "社费缴纳记录（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员用户ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "默认计入缴费时间所在的缴费周期，补缴往期时通过 period_start 指定周期",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "登记社费缴纳（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "缴费信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DuesPaymentReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/dues/schedule": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "社费标准（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "设置社费标准（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "社费标准",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DuesScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "取消社费标准（负责人，已有缴费记录保留）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/handovers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "社团交接记录（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "提名一名正式成员为继任者，继任者接受后完成交接；社团要求时还需管理员确认",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "发起社长交接（社长）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "继任者与原社长去向",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.HandoverReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/invites": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "邀请码列表（负责人，含使用次数）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "创建邀请码（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "邀请码设置",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.InviteCodeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/invites/{id}/redemptions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "邀请码使用记录（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "邀请码ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/invites/{id}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "撤销邀请码（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "邀请码ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/leave-requests": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "社团请假申请列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态，默认pending",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员ID",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/logs": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "日志"
+                ],
+                "summary": "获取操作日志列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作类型",
+                        "name": "actionType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作者姓名",
+                        "name": "operatorName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期(YYYY-MM-DD)",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期(YYYY-MM-DD)",
+                        "name": "endDate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/logs/old": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "获取社团操作日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作类型",
+                        "name": "actionType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作人姓名",
+                        "name": "operatorName",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/mandatory-sessions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "必到场次列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "关联活动时沿用活动起止时间；名单可为全部成员、指定角色或指定成员",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "设置必到场次（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "场次信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MandatorySessionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/members": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "社团成员列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/members/users": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "社团成员列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色: member/leader/advisor",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态: pending/approved/rejected/quit/kicked/expired",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "部门ID",
+                        "name": "departmentId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tagId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键词：姓名/学号/手机号",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "踢出成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "移出原因",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MembershipReasonReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/members/{userId}/role": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "不能任命或撤换社长，社长变更请发起社长交接",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "负责人设定成员角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员用户ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "角色信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SetRoleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/membership-settings": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "设置成员有效期与续期规则（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "有效期设置",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MembershipSettingsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "待审批入会列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键词：姓名/学号",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/batch-approve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "在同一事务中处理，逐条返回结果；非待审核或名额已满的申请跳过",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "批量审批通过（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员关系ID列表",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.BatchReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/batch-reject": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "在同一事务中处理，逐条返回结果；驳回原因对申请人可见",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "批量驳回（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员关系ID列表与驳回原因",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.BatchReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/pending/old": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "待审批入会列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "审批通过成员（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批说明",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MembershipReasonReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/approve/old": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "审批通过成员（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批说明",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MembershipReasonReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "按时间顺序列出成员的申请、审批、角色调整、退社等记录，含操作人与原因",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "成员变更记录（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/notes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "成员备注列表（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "添加成员备注（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "备注内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MemberNoteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/notes/{noteId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "删除成员备注（负责人，仅作者或社长）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "备注ID",
+                        "name": "noteId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "审批驳回成员（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "驳回原因",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RejectMembershipReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/reject/old": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "拒绝成员加入（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/memberships/{id}/tags": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "设置成员标签（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签ID列表",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SetMemberTagsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/old": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "解散社团",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/profile": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Logo、简介、联系方式立即生效；名称与分类的修改生成变更申请，管理员审核通过后生效。\n管理员调用时全部字段直接生效。同一社团同时只能有一条待审核的变更申请。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "修改社团资料（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "社团资料",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.UpdateClubProfileReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/questions": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "新增入社申请问题（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "问题",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ApplicationQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/questions/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "修改入社申请问题（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "问题ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "问题",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ApplicationQuestionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "删除入社申请问题（负责人，已提交的回答保留）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "问题ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/recruitment-settings": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "require_campaign 为 true 时，招新活动以外的时间不接受入社申请",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "设置招新规则（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "招新规则",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RecruitmentSettingsReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/renewals": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "待审核续期申请（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/renewals/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "批准续期（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/renewals/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "驳回续期（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "成员关系ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "社团角色列表（含内置角色与可用权限）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "创建自定义角色（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "角色名称与权限",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ClubRoleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/roles/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "更新自定义角色（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "角色名称与权限",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ClubRoleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "仍有成员使用该角色时不能删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "删除自定义角色（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/roster/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "支持 CSV/XLSX，表头需包含“学号”，可选“姓名、学院、手机、角色”；按学号匹配用户并直接加入社团，\n填写了姓名但与该学号账号的姓名不一致的行报错不导入；“角色”不能是社长，也不能拥有导入者没有的权限。\ndry_run=true 时仅预览不落库；create_accounts=true 时为未注册的学号创建账号（账号即学号），\n未提供 temp_password 时为每个新账号随机生成临时密码并在结果中返回。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "导入成员名册（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "名册文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "仅预览",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "为未注册学号创建账号",
+                        "name": "create_accounts",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "新账号统一临时密码",
+                        "name": "temp_password",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/session-types": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "社团考勤类型列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "创建考勤类型（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "考勤类型",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SessionTypeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/session-types/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "修改考勤类型（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "考勤类型ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "考勤类型",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SessionTypeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "删除考勤类型（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "考勤类型ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/tags": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "成员标签列表（负责人，含使用次数）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "创建成员标签（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MemberTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "更新成员标签（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MemberTagReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "同时移除所有成员上的该标签",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "删除成员标签（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/clubs/{clubId}/users": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "获取社团负责人列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/handovers/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "撤销社长交接（发起人或管理员）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "交接ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/interview-slots/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "修改面试时段（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "面试时段ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "面试时段",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.InterviewSlotReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "删除面试时段（负责人，仅限无人预约）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "面试时段ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/interview-slots/{id}/interviews": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "面试时段预约名单（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "面试时段ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/interviews/{id}/score": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "记录面试评分（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "面试记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "评分与备注",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.InterviewScoreReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/leave-requests/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "批准请假（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "请假ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审核备注",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LeaveReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/leave-requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "驳回请假（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "请假ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审核备注",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LeaveReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/mandatory-sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "取消必到场次（负责人，仅限未生成缺勤的场次）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "场次ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/mandatory-sessions/{id}/absences": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "场次缺勤名单（负责人）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "场次ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/mandatory-sessions/{id}/absences/generate": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "立即生成缺勤记录（负责人，场次结束后）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "场次ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/leader/users/{userId}/clubs": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "负责人"
+                ],
+                "summary": "获取当前用户管理的社团",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID（以当前登录用户为准）",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/absences/my": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "我的缺勤记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/activities/{activityId}/register": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动"
+                ],
+                "summary": "报名状态查询",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动"
+                ],
+                "summary": "报名参加活动",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "活动"
+                ],
+                "summary": "取消报名",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/activities/{activityId}/signin": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "会员签到",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "考勤类型",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SignInReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/activities/{activityId}/signout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "会员签退",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/attendance/my": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "我的考勤记录（按社团）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "termId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "考勤类型编码",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/attendance/sync": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "按客户端时间顺序重放签到/签退事件，规则与在线签到一致；按幂等键去重，逐条返回结果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "离线签到补传",
+                "parameters": [
+                    {
+                        "description": "离线事件",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SyncAttendanceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/clubs/{clubId}/session-types": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "社团考勤类型列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/clubs/{clubId}/signin": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "社团签到（与活动无关）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "考勤类型",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.SignInReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/clubs/{clubId}/signout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "社团签退（与活动无关）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/devices": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "注册签到设备（返回签名密钥，仅返回一次）",
+                "parameters": [
+                    {
+                        "description": "设备信息",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RegisterDeviceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/dues/my": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "社费"
+                ],
+                "summary": "我的社费",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/handovers/my": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "我收到的社长交接",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/handovers/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "社团无需管理员确认时立即完成交接，否则进入待管理员确认",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "接受社长交接（继任者）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "交接ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/handovers/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "成员"
+                ],
+                "summary": "拒绝社长交接（继任者）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "交接ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/leave-requests": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "提交请假申请",
+                "parameters": [
+                    {
+                        "description": "请假信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LeaveReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/leave-requests/my": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "我的请假记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态: pending/approved/rejected/cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/member/leave-requests/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "考勤"
+                ],
+                "summary": "撤回请假申请（仅限待审核）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "请假ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/activities": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "活动列表（公开）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键词",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，current 表示当前学期，默认不限学期",
+                        "name": "termId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/activities/{activityId}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "活动详情（公开）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "活动ID",
+                        "name": "activityId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/announcements": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "公告列表（公开）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "关键词",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/categories": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "社团类别列表（公开）",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/clubs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "社团列表（含类别、代表活动、负责人联系方式）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/clubs/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "注册社团申请",
+                "parameters": [
+                    {
+                        "description": "社团信息",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RegisterClubReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/clubs/{clubId}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "社团详情（公开）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/clubs/{clubId}/alumni": {
+            "get": {
+                "description": "列出已退出或到期、且本人同意公开的前成员，含在社时间与担任过的角色",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "社团校友名录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/clubs/{clubId}/questions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "社团入社申请问题（公开）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "登录",
+                "parameters": [
+                    {
+                        "description": "登录参数",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/recruiting": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "正在招新的社团（公开）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "注册",
+                "parameters": [
+                    {
+                        "description": "注册参数",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RegisterReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/terms": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "学期列表（公开）",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/terms/current": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公共"
+                ],
+                "summary": "当前学期（公开）",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/upload/image": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "上传"
+                ],
+                "summary": "上传图片",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "图片文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/alumni-visibility": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "仅影响已退出或到期后的展示，可随时修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "设置是否出现在校友名录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "是否公开",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.AlumniVisibilityReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/apply": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "社团设置了申请问题时需在请求体中提交回答，可附带附件图片",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "申请加入社团",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "申请回答与附件",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ApplyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/events": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "我在社团的变更记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/exit": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "退出社团",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "退出原因",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MembershipReasonReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/interview": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "我的面试",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "取消面试预约",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/interview-slots": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "可预约的面试时段",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/clubs/{clubId}/renew": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "到期前的续期窗口内或已到期后可申请；社团要求审核时进入待审核",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "续期社团成员资格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "社团ID",
+                        "name": "clubId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/interview-slots/{id}/book": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "预约或改约面试时段",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "面试时段ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/invites/redeem": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "邀请码由负责人发放，不受招新期限制；按邀请码设置直接通过或进入待审核",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "使用邀请码加入社团",
+                "parameters": [
+                    {
+                        "description": "邀请码",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.RedeemInviteReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/me": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "我的信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "更新我的信息",
+                "parameters": [
+                    {
+                        "description": "更新参数",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.MyUpdateReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/memberships/history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "按加入时间倒序列出本人全部成员关系，含在社时间与担任过的角色",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "我的社团经历",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/memberships/my": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "我的社团关系",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "状态: pending/approved/rejected/quit",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "学期ID，默认当前学期，all 表示不限学期",
+                        "name": "termId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "我的通知",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "仅未读",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "标记通知已读",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "通知ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/password": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "学生"
+                ],
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "密码参数",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ChangePasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload/image": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "上传"
+                ],
+                "summary": "上传图片",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "图片文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Body"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.ActivityTypeReq": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "description": "考勤类型编码，空表示清除",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.AlumniVisibilityReq": {
+            "type": "object",
+            "properties": {
+                "visible": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controllers.AnnouncementReq": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "department_id": {
+                    "description": "部门公告，为空表示面向全社团；部门负责人必须指定自己负责的部门",
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ApplicationAnswerReq": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.ApplicationQuestionReq": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "kind": {
+                    "description": "text, choice，默认 text",
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "sort": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ApplyReq": {
+            "type": "object",
+            "properties": {
+                "answers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.ApplicationAnswerReq"
+                    }
+                },
+                "attachment": {
+                    "description": "附件图片地址，通过 /upload/image 上传",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.AttendancePolicyReq": {
+            "type": "object",
+            "properties": {
+                "max_minutes": {
+                    "type": "integer"
+                },
+                "min_minutes": {
+                    "type": "integer"
+                },
+                "rounding_minutes": {
+                    "type": "integer"
+                },
+                "rounding_mode": {
+                    "type": "string"
+                },
+                "signin_end": {
+                    "type": "string"
+                },
+                "signin_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.AuditClubReq": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "approved or rejected",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.BatchReviewReq": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reason": {
+                    "description": "驳回时申请人可见，通过时记入成员变更记录",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.CampaignReq": {
+            "type": "object",
+            "required": [
+                "end_at",
+                "start_at",
+                "title"
+            ],
+            "properties": {
+                "college_quotas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "end_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS",
+                    "type": "string"
+                },
+                "quota": {
+                    "description": "0 表示不限",
+                    "type": "integer"
+                },
+                "start_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ChangePasswordReq": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ClubRoleReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "description": "取值见角色列表返回的 permissions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "controllers.DeleteAttendanceReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.DepartmentMemberReq": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "is_head": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.DepartmentReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "intro": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.DuesPaymentReq": {
+            "type": "object",
+            "required": [
+                "amount",
+                "membership_id",
+                "method"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "membership_id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "cash, wechat, alipay, transfer, other",
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS，默认当前时间",
+                    "type": "string"
+                },
+                "period_start": {
+                    "description": "所缴周期的开始日期 YYYY-MM-DD，默认缴费时间所在周期，用于补缴往期",
+                    "type": "string"
+                },
+                "receipt_no": {
+                    "description": "为空时自动生成",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.DuesScheduleReq": {
+            "type": "object",
+            "required": [
+                "amount",
+                "period_months",
+                "start_date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "block_registration": {
+                    "type": "boolean"
+                },
+                "period_months": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.HandoverReq": {
+            "type": "object",
+            "required": [
+                "to_user_id"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "outgoing_role": {
+                    "description": "member（默认）或 alumni（交接后退社）",
+                    "type": "string"
+                },
+                "to_user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.HandoverSettingsReq": {
+            "type": "object",
+            "properties": {
+                "requires_admin": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controllers.InterviewScoreReq": {
+            "type": "object",
+            "required": [
+                "score"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "0-100",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.InterviewSlotReq": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "end_at",
+                "place",
+                "start_at"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "end_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS",
+                    "type": "string"
+                },
+                "place": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.InviteCodeReq": {
+            "type": "object",
+            "properties": {
+                "auto_approve": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS，为空表示长期有效",
+                    "type": "string"
+                },
+                "max_uses": {
+                    "description": "0 表示不限次数",
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "member, advisor，默认 member",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.LeaveReq": {
+            "type": "object",
+            "required": [
+                "reason",
+                "type"
+            ],
+            "properties": {
+                "activity_id": {
+                    "description": "活动请假时填写，优先于 club_id",
+                    "type": "integer"
+                },
+                "attachment": {
+                    "description": "附件图片地址，通过 /upload/image 上传",
+                    "type": "string"
+                },
+                "club_id": {
+                    "type": "integer"
+                },
+                "leave_date": {
+                    "description": "例会请假日期 YYYY-MM-DD",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "sick, personal, official, other",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.LeaveReviewReq": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.LoginReq": {
+            "type": "object",
+            "required": [
+                "account",
+                "password"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.MandatorySessionReq": {
+            "type": "object",
+            "required": [
+                "roster_mode"
+            ],
+            "properties": {
+                "activity_id": {
+                    "description": "关联活动，为空时需填写起止时间",
+                    "type": "integer"
+                },
+                "end_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS",
+                    "type": "string"
+                },
+                "roster_mode": {
+                    "description": "all, role, list",
+                    "type": "string"
+                },
+                "roster_role": {
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "YYYY-MM-DD HH:MM:SS",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "controllers.MemberNoteReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.MemberTagReq": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.MembershipReasonReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.MembershipSettingsReq": {
+            "type": "object",
+            "properties": {
+                "membership_months": {
+                    "description": "0 表示长期有效",
+                    "type": "integer"
+                },
+                "renewal_requires_approval": {
+                    "type": "boolean"
+                },
+                "renewal_window_days": {
+                    "description": "到期前多少天内可续期",
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.MyUpdateReq": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "college": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "student_no": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.RecruitmentSettingsReq": {
+            "type": "object",
+            "properties": {
+                "require_campaign": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "controllers.RedeemInviteReq": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.RegisterClubReq": {
+            "type": "object",
+            "required": [
+                "account",
+                "category_id",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "account": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "contact": {
+                    "type": "string"
+                },
+                "intro": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.RegisterDeviceReq": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
                 }
             }
         },
@@ -1028,6 +7847,12 @@ const docTemplate = `{
                 "account": {
                     "type": "string"
                 },
+                "college": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -1042,23 +7867,189 @@ const docTemplate = `{
                 }
             }
         },
-        "controllers.UpdateRoleReq": {
+        "controllers.RejectMembershipReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "驳回原因，申请人可见",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ReviewClubChangeReq": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "驳回原因",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.SessionTypeReq": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "ledger": {
+                    "description": "volunteer, second_classroom，空表示不计入台账",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "controllers.SetMemberTagsReq": {
+            "type": "object",
+            "properties": {
+                "tag_ids": {
+                    "description": "覆盖成员现有标签，传空数组表示清空",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "controllers.SetRoleReq": {
             "type": "object",
             "required": [
                 "role"
             ],
             "properties": {
+                "reason": {
+                    "description": "调整原因，记入成员变更记录",
+                    "type": "string"
+                },
                 "role": {
+                    "description": "member, leader, advisor 或社团自定义角色编码",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.SignInReq": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "description": "考勤类型编码，不传则继承活动类型",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.SyncAttendanceReq": {
+            "type": "object",
+            "required": [
+                "device_id",
+                "events"
+            ],
+            "properties": {
+                "device_id": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/controllers.SyncEvent"
+                    }
+                }
+            }
+        },
+        "controllers.SyncEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "signin, signout",
+                    "type": "string"
+                },
+                "activity_id": {
+                    "description": "活动签到时填写，优先于 club_id",
+                    "type": "integer"
+                },
+                "client_time": {
+                    "description": "客户端时间，毫秒时间戳",
+                    "type": "integer"
+                },
+                "club_id": {
+                    "description": "社团签到时填写",
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "客户端生成的幂等键",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "设备签名",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "签到类型，仅 signin 使用",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.TermReq": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "name",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.UpdateClubProfileReq": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "contact": {
+                    "type": "string"
+                },
+                "intro": {
+                    "type": "string"
+                },
+                "logo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "description": "变更申请说明",
                     "type": "string"
                 }
             }
         },
-        "controllers.setRoleReq": {
+        "controllers.UpdateRoleReq": {
             "type": "object",
             "required": [
                 "role"
             ],
             "properties": {
+                "reason": {
+                    "description": "调整原因，记入成员变更记录",
+                    "type": "string"
+                },
                 "role": {
                     "type": "string"
                 }
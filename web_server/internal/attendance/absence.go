@@ -0,0 +1,135 @@
+package attendance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/store"
+	"web_server/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RosterUserIDs 解析必到场次的应到名单，仅包含当前已通过的成员
+func RosterUserIDs(db *gorm.DB, s *models.MandatorySession) ([]uint, error) {
+	q := db.Model(&models.Membership{}).Where("club_id = ? AND status = ?", s.ClubID, "approved")
+	switch s.RosterMode {
+	case "role":
+		q = q.Where("role = ?", s.RosterRole)
+	case "list":
+		q = q.Where("user_id IN (?)", db.Model(&models.MandatoryRosterEntry{}).Select("user_id").Where("session_id = ?", s.ID))
+	}
+	var ids []uint
+	err := q.Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// attendedUserIDs 返回在场次内有有效签到记录的用户
+// 关联活动的场次按活动匹配，否则按时段与社团签到记录是否重叠匹配
+func attendedUserIDs(db *gorm.DB, s *models.MandatorySession) ([]uint, error) {
+	q := db.Model(&models.Attendance{}).Scopes(Valid).Where("club_id = ?", s.ClubID)
+	if s.ActivityID != nil {
+		q = q.Where("activity_id = ?", *s.ActivityID)
+	} else {
+		q = q.Where("signin_at <= ? AND (signout_at IS NULL OR signout_at >= ?)", s.EndAt, s.StartAt)
+	}
+	var ids []uint
+	err := q.Distinct("user_id").Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// excusedUserIDs 返回场次内有已批准请假的用户
+func excusedUserIDs(db *gorm.DB, s *models.MandatorySession) ([]uint, error) {
+	q := db.Model(&models.LeaveRequest{}).Where("club_id = ? AND status = ?", s.ClubID, "approved")
+	if s.ActivityID != nil {
+		q = q.Where("activity_id = ?", *s.ActivityID)
+	} else {
+		q = q.Where("activity_id IS NULL AND leave_date = ?", s.StartAt.In(time.Local).Format("2006-01-02"))
+	}
+	var ids []uint
+	err := q.Distinct("user_id").Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// ExcuseAbsences 请假批准后，将已生成的对应缺勤记录改为已请假
+func ExcuseAbsences(db *gorm.DB, lr *models.LeaveRequest) error {
+	q := db.Model(&models.Absence{}).Where("user_id = ? AND club_id = ? AND status = ?", lr.UserID, lr.ClubID, "absent")
+	if lr.ActivityID != nil {
+		q = q.Where("activity_id = ?", *lr.ActivityID)
+	} else {
+		q = q.Where("activity_id IS NULL AND session_id IN (?)", db.Model(&models.MandatorySession{}).Select("id").
+			Where("club_id = ? AND activity_id IS NULL AND DATE(start_at) = ?", lr.ClubID, lr.LeaveDate.Format("2006-01-02")))
+	}
+	return q.Update("status", "excused").Error
+}
+
+// GenerateAbsences 为已结束场次中未签到的应到成员生成缺勤记录，已批准请假的记为 excused
+// 重复调用不会重复生成
+func GenerateAbsences(s *models.MandatorySession, now time.Time) (int, error) {
+	created := 0
+	err := store.DB().Transaction(func(tx *gorm.DB) error {
+		roster, err := RosterUserIDs(tx, s)
+		if err != nil {
+			return err
+		}
+		attended, err := attendedUserIDs(tx, s)
+		if err != nil {
+			return err
+		}
+		excused, err := excusedUserIDs(tx, s)
+		if err != nil {
+			return err
+		}
+		seen := make(map[uint]bool, len(attended))
+		for _, id := range attended {
+			seen[id] = true
+		}
+		onLeave := make(map[uint]bool, len(excused))
+		for _, id := range excused {
+			onLeave[id] = true
+		}
+		var list []models.Absence
+		for _, id := range roster {
+			if seen[id] {
+				continue
+			}
+			status := "absent"
+			if onLeave[id] {
+				status = "excused"
+			}
+			list = append(list, models.Absence{SessionID: s.ID, ClubID: s.ClubID, UserID: id, ActivityID: s.ActivityID, Status: status})
+		}
+		if len(list) > 0 {
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&list)
+			if res.Error != nil {
+				return res.Error
+			}
+			created = int(res.RowsAffected)
+		}
+		s.ProcessedAt = &now
+		return tx.Model(s).Update("processed_at", now).Error
+	})
+	return created, err
+}
+
+// ProcessEndedSessions 处理所有已结束但尚未生成缺勤的场次，返回成功处理的场次数。
+// 单个场次失败时记录日志并继续处理其余场次，最后返回合并后的错误
+func ProcessEndedSessions(now time.Time) (int, error) {
+	var list []models.MandatorySession
+	if err := store.DB().Where("processed_at IS NULL AND end_at <= ?", now).Find(&list).Error; err != nil {
+		return 0, err
+	}
+	n := 0
+	var errs []error
+	for i := range list {
+		if _, err := GenerateAbsences(&list[i], now); err != nil {
+			logger.Error(fmt.Sprintf("generate absences failed: session_id=%d", list[i].ID), err)
+			errs = append(errs, fmt.Errorf("session %d: %w", list[i].ID, err))
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
@@ -0,0 +1,148 @@
+package attendance
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"web_server/config"
+	"web_server/db/models"
+	"web_server/internal/store"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	KindOverlap       = "overlap"
+	KindLongSession   = "long_session"
+	KindBurst         = "burst"
+	KindOutsideWindow = "outside_window"
+)
+
+// Valid 过滤已作废的考勤记录，统计时长时使用
+func Valid(db *gorm.DB) *gorm.DB {
+	return db.Where("attendances.voided_at IS NULL")
+}
+
+// Analyze 扫描 since 之后在指定社团内的签到记录，返回发现的异常标记（未入库）
+// 跨社团重叠需要读取同一用户在其他社团的记录，但只标记指定社团内的记录
+func Analyze(clubIDs []uint, since time.Time, cfg config.AttendanceConfig, now time.Time) ([]models.AttendanceFlag, error) {
+	var scoped []models.Attendance
+	q := store.DB().Model(&models.Attendance{}).Scopes(Valid).Where("signin_at >= ?", since).Preload("Activity")
+	if clubIDs != nil {
+		q = q.Where("club_id IN ?", clubIDs)
+	}
+	if err := q.Find(&scoped).Error; err != nil {
+		return nil, err
+	}
+	if len(scoped) == 0 {
+		return nil, nil
+	}
+	inScope := make(map[uint]bool, len(scoped))
+	userIDs := make([]uint, 0)
+	seenUser := map[uint]bool{}
+	for _, a := range scoped {
+		inScope[a.ID] = true
+		if !seenUser[a.UserID] {
+			seenUser[a.UserID] = true
+			userIDs = append(userIDs, a.UserID)
+		}
+	}
+	var all []models.Attendance
+	if err := store.DB().Model(&models.Attendance{}).Scopes(Valid).
+		Where("signin_at >= ? AND user_id IN ?", since, userIDs).Find(&all).Error; err != nil {
+		return nil, err
+	}
+
+	var flags []models.AttendanceFlag
+	add := func(a models.Attendance, kind, detail string) {
+		if inScope[a.ID] {
+			flags = append(flags, models.AttendanceFlag{AttendanceID: a.ID, ClubID: a.ClubID, UserID: a.UserID, Kind: kind, Detail: detail, Status: "pending"})
+		}
+	}
+	end := func(a models.Attendance) time.Time {
+		if a.SignoutAt != nil {
+			return *a.SignoutAt
+		}
+		return now
+	}
+
+	byUser := map[uint][]models.Attendance{}
+	for _, a := range all {
+		if a.SigninAt != nil {
+			byUser[a.UserID] = append(byUser[a.UserID], a)
+		}
+	}
+	maxSession := time.Duration(cfg.MaxSessionHours) * time.Hour
+	burstWindow := time.Duration(cfg.BurstWindowSeconds) * time.Second
+	for _, list := range byUser {
+		sort.Slice(list, func(i, j int) bool { return list[i].SigninAt.Before(*list[j].SigninAt) })
+		for i, a := range list {
+			// 跨社团时间重叠
+			for j := i + 1; j < len(list) && list[j].SigninAt.Before(end(a)); j++ {
+				b := list[j]
+				if b.ClubID != a.ClubID {
+					add(a, KindOverlap, fmt.Sprintf("与社团 %d 的考勤记录 %d 时间重叠", b.ClubID, b.ID))
+					add(b, KindOverlap, fmt.Sprintf("与社团 %d 的考勤记录 %d 时间重叠", a.ClubID, a.ID))
+				}
+			}
+			// 单次时长过长，包括长时间未签退
+			if maxSession > 0 && end(a).Sub(*a.SigninAt) > maxSession {
+				add(a, KindLongSession, fmt.Sprintf("时长 %.1f 小时，超过 %d 小时", end(a).Sub(*a.SigninAt).Hours(), cfg.MaxSessionHours))
+			}
+			// 短时间内频繁签到
+			if cfg.BurstCount > 0 {
+				k := i
+				for k < len(list) && list[k].SigninAt.Sub(*a.SigninAt) <= burstWindow {
+					k++
+				}
+				if k-i >= cfg.BurstCount {
+					for _, b := range list[i:k] {
+						add(b, KindBurst, fmt.Sprintf("%d 秒内签到 %d 次", cfg.BurstWindowSeconds, k-i))
+					}
+				}
+			}
+		}
+	}
+
+	// 活动签到不在活动时间范围内
+	grace := time.Duration(cfg.WindowGraceMinutes) * time.Minute
+	for _, a := range scoped {
+		if a.ActivityID == nil || a.SigninAt == nil {
+			continue
+		}
+		if a.Activity.StartAt != nil && a.SigninAt.Before(a.Activity.StartAt.Add(-grace)) {
+			add(a, KindOutsideWindow, "签到早于活动开始时间")
+		} else if a.Activity.EndAt != nil && a.SigninAt.After(a.Activity.EndAt.Add(grace)) {
+			add(a, KindOutsideWindow, "签到晚于活动结束时间")
+		}
+	}
+	return dedupFlags(flags), nil
+}
+
+// SaveFlags 写入异常标记，同一记录同类异常只保留一条，返回新增数量
+func SaveFlags(flags []models.AttendanceFlag) (int64, error) {
+	if len(flags) == 0 {
+		return 0, nil
+	}
+	res := store.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&flags)
+	return res.RowsAffected, res.Error
+}
+
+func dedupFlags(flags []models.AttendanceFlag) []models.AttendanceFlag {
+	type key struct {
+		id   uint
+		kind string
+	}
+	seen := map[key]bool{}
+	out := make([]models.AttendanceFlag, 0, len(flags))
+	for _, f := range flags {
+		k := key{f.AttendanceID, f.Kind}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, f)
+	}
+	return out
+}
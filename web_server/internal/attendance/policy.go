@@ -0,0 +1,136 @@
+package attendance
+
+import (
+	"errors"
+	"math"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/store"
+)
+
+var ErrOutsideSigninHours = errors.New("不在允许签到的时间段内")
+
+// DefaultPolicy 社团未配置规则时使用，与原有逻辑一致：不足1分钟不计，按0.01小时四舍五入
+func DefaultPolicy(clubID uint) models.AttendancePolicy {
+	return models.AttendancePolicy{ClubID: clubID, Version: 0, MinMinutes: 1, RoundingMode: "nearest"}
+}
+
+// CurrentPolicy 返回社团最新版本的考勤规则
+func CurrentPolicy(clubID uint) models.AttendancePolicy {
+	var p models.AttendancePolicy
+	if err := store.DB().Where("club_id = ?", clubID).Order("version DESC").First(&p).Error; err != nil {
+		return DefaultPolicy(clubID)
+	}
+	return p
+}
+
+// Validate 校验规则参数
+func Validate(p models.AttendancePolicy) error {
+	if p.MinMinutes < 0 || p.MaxMinutes < 0 {
+		return errors.New("时长不能为负数")
+	}
+	if p.MaxMinutes > 0 && p.MaxMinutes < p.MinMinutes {
+		return errors.New("最大计入时长不能小于最小时长")
+	}
+	switch p.RoundingMinutes {
+	case 0, 5, 10, 15, 30, 60:
+	default:
+		return errors.New("取整粒度仅支持 0/5/10/15/30/60 分钟")
+	}
+	switch p.RoundingMode {
+	case "down", "nearest", "up":
+	default:
+		return errors.New("取整方式仅支持 down/nearest/up")
+	}
+	if (p.SigninStart == "") != (p.SigninEnd == "") {
+		return errors.New("签到时间段需同时设置开始与结束")
+	}
+	if p.SigninStart != "" {
+		if _, ok := clockMinutes(p.SigninStart); !ok {
+			return errors.New("签到开始时间格式应为 HH:MM")
+		}
+		if _, ok := clockMinutes(p.SigninEnd); !ok {
+			return errors.New("签到结束时间格式应为 HH:MM")
+		}
+	}
+	return nil
+}
+
+// CheckSignIn 校验签到时间是否在允许的时间段内，结束早于开始时视为跨越零点
+func CheckSignIn(p models.AttendancePolicy, at time.Time) error {
+	if p.SigninStart == "" || p.SigninEnd == "" {
+		return nil
+	}
+	start, ok1 := clockMinutes(p.SigninStart)
+	end, ok2 := clockMinutes(p.SigninEnd)
+	if !ok1 || !ok2 {
+		return nil
+	}
+	at = at.In(time.Local)
+	cur := at.Hour()*60 + at.Minute()
+	if start <= end {
+		if cur >= start && cur <= end {
+			return nil
+		}
+	} else if cur >= start || cur <= end {
+		return nil
+	}
+	return ErrOutsideSigninHours
+}
+
+// Settle 按规则结算签退，写入签退时间、计入时长与规则版本
+// 返回 false 表示时长不足最小时长，该记录不应计入
+func Settle(p models.AttendancePolicy, att *models.Attendance, signoutAt time.Time) bool {
+	att.SignoutAt = &signoutAt
+	att.PolicyVersion = p.Version
+	if att.SigninAt == nil {
+		return true
+	}
+	d := signoutAt.Sub(*att.SigninAt)
+	if d < time.Duration(p.MinMinutes)*time.Minute {
+		return false
+	}
+	if p.MaxMinutes > 0 && d > time.Duration(p.MaxMinutes)*time.Minute {
+		d = time.Duration(p.MaxMinutes) * time.Minute
+	}
+	if p.RoundingMinutes <= 0 {
+		att.DurationMinutes = int(d.Minutes())
+		att.DurationHours = math.Round(d.Hours()*100) / 100
+		return true
+	}
+	units := d.Minutes() / float64(p.RoundingMinutes)
+	switch p.RoundingMode {
+	case "down":
+		units = math.Floor(units)
+	case "up":
+		units = math.Ceil(units)
+	default:
+		units = math.Round(units)
+	}
+	minutes := int(units) * p.RoundingMinutes
+	// 向上或四舍五入可能超过最大时长，取不超过上限的最大取整倍数
+	if p.MaxMinutes > 0 && minutes > p.MaxMinutes {
+		minutes = p.MaxMinutes / p.RoundingMinutes * p.RoundingMinutes
+	}
+	att.DurationMinutes = minutes
+	att.DurationHours = math.Round(float64(minutes)/60*100) / 100
+	return true
+}
+
+// Finish 按社团当前规则结算签退并计算加权时长，返回值含义同 Settle
+func Finish(att *models.Attendance, signoutAt time.Time) bool {
+	if !Settle(CurrentPolicy(att.ClubID), att, signoutAt) {
+		return false
+	}
+	applyWeight(att)
+	return true
+}
+
+// clockMinutes 将 HH:MM 转换为当日分钟数
+func clockMinutes(s string) (int, bool) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
@@ -0,0 +1,55 @@
+package attendance
+
+import (
+	"errors"
+	"math"
+	"web_server/db/models"
+	"web_server/internal/store"
+)
+
+var ErrUnknownSessionType = errors.New("考勤类型不存在")
+
+// Ledgers 考勤类型可计入的台账
+var Ledgers = map[string]string{
+	"volunteer":        "志愿时长",
+	"second_classroom": "第二课堂学分",
+}
+
+// ResolveType 确定签到的考勤类型：优先使用显式指定的类型，否则继承活动的默认类型
+// 未指定类型时返回零值，表示普通考勤
+func ResolveType(clubID uint, code string, act *models.Activity) (models.SessionType, error) {
+	explicit := code != ""
+	if !explicit && act != nil {
+		code = act.Type
+	}
+	if code == "" {
+		return models.SessionType{}, nil
+	}
+	var st models.SessionType
+	if err := store.DB().Where("club_id = ? AND code = ?", clubID, code).First(&st).Error; err != nil {
+		if explicit {
+			return models.SessionType{}, ErrUnknownSessionType
+		}
+		// 活动引用的类型已被删除时按普通考勤处理
+		return models.SessionType{}, nil
+	}
+	return st, nil
+}
+
+// typeWeight 返回考勤类型的时长权重，未设置类型时为1
+func typeWeight(clubID uint, code string) float64 {
+	if code == "" {
+		return 1
+	}
+	var st models.SessionType
+	if err := store.DB().Where("club_id = ? AND code = ?", clubID, code).First(&st).Error; err != nil {
+		return 1
+	}
+	return st.Weight
+}
+
+// applyWeight 按考勤类型权重计算加权时长
+func applyWeight(att *models.Attendance) {
+	w := typeWeight(att.ClubID, att.Type)
+	att.WeightedHours = math.Round(att.DurationHours*w*100) / 100
+}
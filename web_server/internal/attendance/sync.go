@@ -0,0 +1,32 @@
+package attendance
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// NewDeviceSecret 生成设备签名密钥
+func NewDeviceSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SignEvent 计算离线事件签名：hex(HMAC-SHA256(secret, "key|action|target|clientTimeMs"))
+// target 为活动签到时的 activity:<id> 或社团签到时的 club:<id>
+func SignEvent(secret, key, action, target string, clientTimeMs int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s|%s|%s|%d", key, action, target, clientTimeMs)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyEvent 校验离线事件签名
+func VerifyEvent(secret, key, action, target string, clientTimeMs int64, signature string) bool {
+	want := SignEvent(secret, key, action, target, clientTimeMs)
+	return hmac.Equal([]byte(want), []byte(signature))
+}
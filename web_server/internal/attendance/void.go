@@ -0,0 +1,55 @@
+package attendance
+
+import (
+	"errors"
+	"time"
+	"web_server/db/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrNotVoided = errors.New("该记录未作废")
+
+// Void 作废考勤记录，原始签到数据保留，统计与列表默认不再计入
+func Void(db *gorm.DB, att *models.Attendance, by uint, reason string) error {
+	now := time.Now()
+	att.VoidedAt = &now
+	att.VoidedBy = by
+	att.VoidReason = reason
+	return db.Model(att).Updates(map[string]any{"voided_at": now, "voided_by": by, "void_reason": reason}).Error
+}
+
+// Restore 恢复已作废的考勤记录，随记录一起作废的异常标记退回待审核
+func Restore(db *gorm.DB, att *models.Attendance) error {
+	if att.VoidedAt == nil {
+		return ErrNotVoided
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(att).Updates(map[string]any{"voided_at": nil, "voided_by": 0, "void_reason": ""}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.AttendanceFlag{}).Where("attendance_id = ? AND status = ?", att.ID, "voided").
+			Updates(map[string]any{"status": "pending", "reviewer_id": 0, "reviewed_at": nil}).Error
+	})
+	if err != nil {
+		return err
+	}
+	att.VoidedAt = nil
+	att.VoidedBy = 0
+	att.VoidReason = ""
+	return nil
+}
+
+// ReasonTooShort 时长不足最小时长时自动作废的原因
+const ReasonTooShort = "时长不足，不计入"
+
+// SaveSettled 保存结算后的签退记录，时长不足的记录作废保留而非删除
+func SaveSettled(db *gorm.DB, att *models.Attendance, counted bool, by uint) error {
+	if err := db.Save(att).Error; err != nil {
+		return err
+	}
+	if !counted {
+		return Void(db, att, by, ReasonTooShort)
+	}
+	return nil
+}
@@ -7,9 +7,10 @@ import (
 
 func IsAdmin(u *models.User) bool { return u.Role.Code == "admin" }
 
+// IsClubLeader 判断用户是否为社团的在任负责人或指导老师，已退社或被移出的不再具有权限
 func IsClubLeader(userID uint, clubID uint) bool {
 	var m models.Membership
-	if err := store.DB().Where("user_id = ? AND club_id = ? AND role IN ?", userID, clubID, []string{"leader", "advisor"}).First(&m).Error; err != nil {
+	if err := store.DB().Where("user_id = ? AND club_id = ? AND role IN ? AND status = ?", userID, clubID, []string{"leader", "advisor"}, "approved").First(&m).Error; err != nil {
 		return false
 	}
 	return true
@@ -17,8 +18,29 @@ func IsClubLeader(userID uint, clubID uint) bool {
 
 func IsClubMember(userID uint, clubID uint) bool {
 	var m models.Membership
-	if err := store.DB().Where("user_id = ? AND club_id = ?", userID, clubID).First(&m).Error; err != nil {
+	if err := store.DB().Where("user_id = ? AND club_id = ? AND status = ?", userID, clubID, "approved").First(&m).Error; err != nil {
 		return false
 	}
 	return true
 }
+
+// HeadedDepartments 返回用户在社团内负责的部门，已离开社团的不再具有负责人权限
+func HeadedDepartments(userID uint, clubID uint) []uint {
+	var ids []uint
+	if !IsClubMember(userID, clubID) {
+		return ids
+	}
+	store.DB().Model(&models.DepartmentMember{}).Where("user_id = ? AND club_id = ? AND is_head = ?", userID, clubID, true).Pluck("department_id", &ids)
+	return ids
+}
+
+// IsDepartmentHeadOf 判断用户是否负责成员 memberID 所在的某个部门
+func IsDepartmentHeadOf(userID uint, clubID uint, memberID uint) bool {
+	heads := HeadedDepartments(userID, clubID)
+	if len(heads) == 0 {
+		return false
+	}
+	var cnt int64
+	store.DB().Model(&models.DepartmentMember{}).Where("user_id = ? AND department_id IN ?", memberID, heads).Count(&cnt)
+	return cnt > 0
+}
@@ -0,0 +1,127 @@
+package authz
+
+import (
+	"web_server/db/models"
+	"web_server/internal/store"
+)
+
+// 社团内权限
+const (
+	PermApproveMembers       = "approve_members"       // 审批入社申请与续期、导入名册
+	PermManageMembers        = "manage_members"        // 查看与移出成员，维护部门、标签与备注
+	PermManageRoles          = "manage_roles"          // 设置成员角色、维护自定义角色
+	PermPublishAnnouncements = "publish_announcements" // 发布公告
+	PermManageAttendance     = "manage_attendance"     // 查看与修正考勤，维护考勤类型、规则、必到场次与请假审批
+	PermViewLogs             = "view_logs"             // 查看操作日志
+	PermManageRecruitment    = "manage_recruitment"    // 招新活动、申请问题、面试与邀请码
+	PermManageDues           = "manage_dues"           // 会费标准与缴费记录
+	PermManageClub           = "manage_club"           // 社团资料与成员有效期设置
+)
+
+// AllPermissions 可分配给自定义角色的权限
+var AllPermissions = []string{PermApproveMembers, PermManageMembers, PermManageRoles, PermPublishAnnouncements, PermManageAttendance, PermViewLogs, PermManageRecruitment, PermManageDues, PermManageClub}
+
+// BuiltInRoles 内置角色：社长与指导老师拥有全部权限，普通成员没有管理权限
+var BuiltInRoles = []models.ClubRole{
+	{Code: "leader", Name: "社长", Permissions: AllPermissions, BuiltIn: true},
+	{Code: "advisor", Name: "指导老师", Permissions: AllPermissions, BuiltIn: true},
+	{Code: "member", Name: "成员", Permissions: []string{}, BuiltIn: true},
+}
+
+// IsBuiltInRole 判断是否为内置角色编码
+func IsBuiltInRole(code string) bool {
+	for _, r := range BuiltInRoles {
+		if r.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// FindClubRole 按编码查找社团角色，先查内置角色再查自定义角色
+func FindClubRole(clubID uint, code string) (models.ClubRole, bool) {
+	for _, r := range BuiltInRoles {
+		if r.Code == code {
+			return r, true
+		}
+	}
+	var r models.ClubRole
+	if err := store.DB().Where("club_id = ? AND code = ?", clubID, code).First(&r).Error; err != nil {
+		return r, false
+	}
+	return r, true
+}
+
+// RolePermissions 返回角色拥有的权限，未知角色没有任何权限
+func RolePermissions(clubID uint, code string) []string {
+	r, ok := FindClubRole(clubID, code)
+	if !ok {
+		return nil
+	}
+	return r.Permissions
+}
+
+// HasClubPermission 判断用户在社团内是否拥有指定权限，管理员拥有全部权限
+func HasClubPermission(u *models.User, clubID uint, perm string) bool {
+	if IsAdmin(u) {
+		return true
+	}
+	var m models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ? AND status = ?", u.ID, clubID, "approved").First(&m).Error; err != nil {
+		return false
+	}
+	return containsPerm(RolePermissions(clubID, m.Role), perm)
+}
+
+// ClubsWithPermission 返回用户拥有指定权限的社团
+func ClubsWithPermission(userID uint, perm string) []uint {
+	var list []models.Membership
+	store.DB().Where("user_id = ? AND status = ?", userID, "approved").Find(&list)
+	ids := make([]uint, 0, len(list))
+	for _, m := range list {
+		if containsPerm(RolePermissions(m.ClubID, m.Role), perm) {
+			ids = append(ids, m.ClubID)
+		}
+	}
+	return ids
+}
+
+// Outranks 判断 callerRole 能否管理 targetRole：社长高于其他所有角色，
+// 其余角色须拥有目标角色的全部权限且比其多至少一项
+func Outranks(clubID uint, callerRole, targetRole string) bool {
+	if targetRole == "leader" {
+		return false
+	}
+	if callerRole == "leader" {
+		return true
+	}
+	mine, theirs := RolePermissions(clubID, callerRole), RolePermissions(clubID, targetRole)
+	return CoversPermissions(mine, theirs) && !CoversPermissions(theirs, mine)
+}
+
+// CanGrant 判断 callerRole 能否把成员设为 newRole：只有社长能任命社长，其余不能赋予自己没有的权限
+func CanGrant(clubID uint, callerRole string, newRole models.ClubRole) bool {
+	if newRole.Code == "leader" {
+		return callerRole == "leader"
+	}
+	return CoversPermissions(RolePermissions(clubID, callerRole), newRole.Permissions)
+}
+
+// CoversPermissions 判断 have 是否包含 want 中的全部权限
+func CoversPermissions(have, want []string) bool {
+	for _, p := range want {
+		if !containsPerm(have, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPerm(perms []string, perm string) bool {
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
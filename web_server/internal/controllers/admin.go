@@ -1,21 +1,27 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"web_server/db/models"
+	"web_server/internal/attendance"
 	"web_server/internal/authz"
+	"web_server/internal/membership"
 	"web_server/internal/store"
 	"web_server/pkg/response"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type UpdateRoleReq struct {
-	Role string `json:"role" binding:"required"`
+	Role   string `json:"role" binding:"required"`
+	Reason string `json:"reason"` // 调整原因，记入成员变更记录
 }
 
 // @Summary 更新成员社团内角色
+// @Description 不能任命或撤换社长，社长变更请发起社长交接
 // @Tags 管理员
 // @Accept json
 // @Produce json
@@ -36,19 +42,31 @@ func UpdateMembershipRole(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
-	switch req.Role {
-	case "member", "leader", "advisor":
-	default:
-		c.JSON(http.StatusBadRequest, response.Error(400, "非法角色"))
-		return
-	}
 	var m models.Membership
 	if err := store.DB().Where("id = ?", id).First(&m).Error; err != nil {
 		c.JSON(http.StatusNotFound, response.Error(404, "不存在"))
 		return
 	}
+	if _, ok := authz.FindClubRole(m.ClubID, req.Role); !ok {
+		c.JSON(http.StatusBadRequest, response.Error(400, "非法角色"))
+		return
+	}
+	if touchesLeader(m.Role, req.Role) {
+		c.JSON(http.StatusBadRequest, response.Error(400, errLeaderViaHandover))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	fromRole := m.Role
 	m.Role = req.Role
-	if err := store.DB().Save(&m).Error; err != nil {
+	membership.NoteRole(&m)
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&m).Error; err != nil {
+			return err
+		}
+		return membership.RecordRoleChange(tx, &m, fromRole, u.ID, req.Reason)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
 		return
 	}
@@ -135,9 +153,13 @@ func AuditClub(c *gin.Context) {
 // @Param user_name query string false "成员名字"
 // @Param student_no query string false "学号"
 // @Param date query string false "日期(YYYY-MM-DD)"
+// @Param term_id query string false "学期ID，默认当前学期，all 表示不限学期"
+// @Param type query string false "考勤类型编码"
+// @Param department_id query int false "部门ID，部门负责人可查看本部门"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /leader/attendance/list [get]
+// @Router /admin/attendance [get]
 func ListManagedAttendance(c *gin.Context) {
 	pageStr := c.DefaultQuery("page", "1")
 	sizeStr := c.DefaultQuery("size", "10")
@@ -146,72 +168,72 @@ func ListManagedAttendance(c *gin.Context) {
 	offset := (page - 1) * size
 
 	clubName := c.Query("club_name")
-	clubIDStr := c.Query("club_id")
 	userName := c.Query("user_name")
 	studentNo := c.Query("student_no")
 	dateStr := c.Query("date")
+	term, ok := termFilter(c, "term_id")
+	if !ok {
+		return
+	}
 
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
 
-	db := store.DB().Model(&models.Attendance{})
-
-	// 权限控制：如果不是管理员，只能查看自己负责的社团的考勤
-	if !authz.IsAdmin(u) {
-		var clubIDs []uint
-		store.DB().Model(&models.Membership{}).
-			Where("user_id = ? AND role IN ?", u.ID, []string{"leader", "advisor"}).
-			Pluck("club_id", &clubIDs)
+	db := store.DB().Model(&models.Attendance{}).Scopes(attendance.Valid)
+	lq := store.DB().Model(&models.LeaveRequest{})
 
+	// 权限控制：如果不是管理员，只能查看自己负责的社团或部门的考勤
+	clubIDs, all, dept, ok := departmentReportScope(c, u)
+	if !ok {
+		return
+	}
+	if dept != nil {
+		db = db.Where("attendances.user_id IN (?)", departmentUsers([]uint{dept.ID}))
+		lq = lq.Where("leave_requests.user_id IN (?)", departmentUsers([]uint{dept.ID}))
+	}
+	if !all {
 		if len(clubIDs) == 0 {
 			// 如果没有管理的社团，直接返回空列表
 			c.JSON(http.StatusOK, response.Success(map[string]any{
-				"list":  []models.Attendance{},
-				"total": 0,
+				"list":   []models.Attendance{},
+				"total":  0,
+				"leaves": []models.LeaveRequest{},
 			}))
 			return
 		}
-		// 如果传入了club_id，必须检查该club_id是否在管理的社团列表中
-		if clubIDStr != "" {
-			cid, _ := strconv.Atoi(clubIDStr)
-			allowed := false
-			for _, id := range clubIDs {
-				if id == uint(cid) {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				c.JSON(http.StatusForbidden, response.Error(403, "无权限查看该社团考勤"))
-				return
-			}
-			db = db.Where("attendances.club_id = ?", cid)
-		} else {
-			db = db.Where("attendances.club_id IN ?", clubIDs)
-		}
-	} else {
-		// 管理员：如果传入了club_id，则按club_id筛选
-		if clubIDStr != "" {
-			db = db.Where("attendances.club_id = ?", clubIDStr)
-		}
+		db = db.Where("attendances.club_id IN ?", clubIDs)
+		lq = lq.Where("leave_requests.club_id IN ?", clubIDs)
 	}
 
 	if clubName != "" {
 		db = db.Joins("JOIN clubs ON clubs.id = attendances.club_id").Where("clubs.name LIKE ?", "%"+clubName+"%")
+		lq = lq.Joins("JOIN clubs ON clubs.id = leave_requests.club_id").Where("clubs.name LIKE ?", "%"+clubName+"%")
 	}
 	if userName != "" || studentNo != "" {
 		db = db.Joins("JOIN users ON users.id = attendances.user_id")
+		lq = lq.Joins("JOIN users ON users.id = leave_requests.user_id")
 		if userName != "" {
 			db = db.Where("users.name LIKE ?", "%"+userName+"%")
+			lq = lq.Where("users.name LIKE ?", "%"+userName+"%")
 		}
 		if studentNo != "" {
 			db = db.Where("users.student_no LIKE ?", "%"+studentNo+"%")
+			lq = lq.Where("users.student_no LIKE ?", "%"+studentNo+"%")
 		}
 	}
 	if dateStr != "" {
 		// 筛选签到时间或签退时间匹配日期的记录
 		// MySQL DATE() function
 		db = db.Where("DATE(attendances.signin_at) = ? OR DATE(attendances.signout_at) = ?", dateStr, dateStr)
+		lq = lq.Where("leave_requests.leave_date = ?", dateStr)
+	}
+	if term != nil {
+		start, end := termBounds(term)
+		db = db.Where("attendances.signin_at BETWEEN ? AND ?", start, end)
+		lq = lq.Where("leave_requests.leave_date BETWEEN ? AND ?", start, end)
+	}
+	if typ := c.Query("type"); typ != "" {
+		db = db.Where("attendances.type = ?", typ)
 	}
 
 	var total int64
@@ -225,7 +247,37 @@ func ListManagedAttendance(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, response.Success(map[string]any{
-		"list":  list,
-		"total": total,
+		"list":   list,
+		"total":  total,
+		"leaves": approvedLeaves(lq),
 	}))
 }
+
+// managedClubScope 计算当前用户可管理的考勤社团范围
+// 管理员且未指定社团时 all=true；否则返回可查看的社团ID列表。
+// 指定了 clubIDStr 但不在负责范围内时 allowed=false；clubIDStr 不是合法ID时返回错误。
+func managedClubScope(u *models.User, clubIDStr string) (clubIDs []uint, all bool, allowed bool, err error) {
+	cid := 0
+	if clubIDStr != "" {
+		if cid, err = strconv.Atoi(clubIDStr); err != nil || cid <= 0 {
+			return nil, false, false, errors.New("invalid club_id")
+		}
+	}
+	if authz.IsAdmin(u) {
+		if cid == 0 {
+			return nil, true, true, nil
+		}
+		return []uint{uint(cid)}, false, true, nil
+	}
+	clubIDs = authz.ClubsWithPermission(u.ID, authz.PermManageAttendance)
+	if cid == 0 {
+		return clubIDs, false, true, nil
+	}
+	// 如果传入了club_id，必须检查该club_id是否在管理的社团列表中
+	for _, id := range clubIDs {
+		if id == uint(cid) {
+			return []uint{id}, false, true, nil
+		}
+	}
+	return nil, false, false, nil
+}
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/membership"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AlumniItem struct {
+	UserID   uint       `json:"user_id"`
+	Name     string     `json:"name"`
+	Avatar   string     `json:"avatar"`
+	College  string     `json:"college"`
+	JoinedAt *time.Time `json:"joined_at"`
+	LeftAt   *time.Time `json:"left_at"`
+	Roles    []string   `json:"roles"` // 担任过的角色名称
+}
+
+type ClubHistoryItem struct {
+	MembershipID  uint       `json:"membership_id"`
+	ClubID        uint       `json:"club_id"`
+	ClubName      string     `json:"club_name"`
+	ClubLogo      string     `json:"club_logo"`
+	Status        string     `json:"status"`
+	Role          string     `json:"role"`
+	JoinedAt      *time.Time `json:"joined_at"`
+	LeftAt        *time.Time `json:"left_at"`
+	Roles         []string   `json:"roles"`
+	AlumniVisible bool       `json:"alumni_visible"`
+}
+
+type AlumniVisibilityReq struct {
+	Visible bool `json:"visible"`
+}
+
+// roleNamesOf 返回各社团角色编码到名称的映射，含内置角色
+func roleNamesOf(clubIDs []uint) map[uint]map[string]string {
+	names := make(map[uint]map[string]string, len(clubIDs))
+	for _, id := range clubIDs {
+		names[id] = map[string]string{}
+		for _, r := range authz.BuiltInRoles {
+			names[id][r.Code] = r.Name
+		}
+	}
+	if len(clubIDs) > 0 {
+		var custom []models.ClubRole
+		_ = store.DB().Where("club_id IN ?", clubIDs).Find(&custom).Error
+		for _, r := range custom {
+			names[r.ClubID][r.Code] = r.Name
+		}
+	}
+	return names
+}
+
+// roleLabels 将角色编码翻译为名称，已删除的自定义角色保留编码
+func roleLabels(names map[string]string, codes []string) []string {
+	out := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if n, ok := names[code]; ok {
+			out = append(out, n)
+		} else {
+			out = append(out, code)
+		}
+	}
+	return out
+}
+
+// @Summary 社团校友名录
+// @Description 列出已退出或到期、且本人同意公开的前成员，含在社时间与担任过的角色
+// @Tags 公共
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} response.Body
+// @Router /public/clubs/{clubId}/alumni [get]
+func ListClubAlumni(c *gin.Context) {
+	clubID, err := strconv.Atoi(c.Param("clubId"))
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var club models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&club).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
+		return
+	}
+	q := store.DB().Model(&models.Membership{}).
+		Where("club_id = ? AND status IN ? AND alumni_visible = ?", clubID, membership.AlumniStatuses, true).
+		Preload("User").Order("left_at DESC, id DESC")
+	var list []models.Membership
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q, pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	names := roleNamesOf([]uint{club.ID})[club.ID]
+	items := make([]AlumniItem, 0, len(list))
+	for _, m := range list {
+		items = append(items, AlumniItem{
+			UserID:   m.UserID,
+			Name:     m.User.Name,
+			Avatar:   m.User.Avatar,
+			College:  m.User.College,
+			JoinedAt: membership.JoinedSince(m),
+			LeftAt:   membership.LeftSince(m),
+			Roles:    roleLabels(names, membership.RolesOf(m)),
+		})
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": items, "pagination": info}))
+}
+
+// @Summary 我的社团经历
+// @Description 按加入时间倒序列出本人全部成员关系，含在社时间与担任过的角色
+// @Tags 学生
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/memberships/history [get]
+func MyClubHistory(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var list []models.Membership
+	if err := store.DB().Where("user_id = ?", u.ID).Preload("Club").Order("id DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	clubIDs := make([]uint, 0, len(list))
+	for _, m := range list {
+		clubIDs = append(clubIDs, m.ClubID)
+	}
+	names := roleNamesOf(clubIDs)
+	items := make([]ClubHistoryItem, 0, len(list))
+	for _, m := range list {
+		items = append(items, ClubHistoryItem{
+			MembershipID:  m.ID,
+			ClubID:        m.ClubID,
+			ClubName:      m.Club.Name,
+			ClubLogo:      m.Club.Logo,
+			Status:        m.Status,
+			Role:          m.Role,
+			JoinedAt:      membership.JoinedSince(m),
+			LeftAt:        membership.LeftSince(m),
+			Roles:         roleLabels(names[m.ClubID], membership.RolesOf(m)),
+			AlumniVisible: m.AlumniVisible,
+		})
+	}
+	// 从未加入的申请排在最后，其余按加入时间倒序
+	sortHistory(items)
+	c.JSON(http.StatusOK, response.Success(items))
+}
+
+func sortHistory(items []ClubHistoryItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].JoinedAt, items[j].JoinedAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+}
+
+// @Summary 设置是否出现在校友名录
+// @Description 仅影响已退出或到期后的展示，可随时修改
+// @Tags 学生
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body AlumniVisibilityReq true "是否公开"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/clubs/{clubId}/alumni-visibility [put]
+func SetAlumniVisibility(c *gin.Context) {
+	clubID, err := strconv.Atoi(c.Param("clubId"))
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var req AlumniVisibilityReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var m models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ?", u.ID, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "未加入该社团"))
+		return
+	}
+	if err := store.DB().Model(&m).Update("alumni_visible", req.Visible).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"club_id": clubID, "alumni_visible": req.Visible}))
+}
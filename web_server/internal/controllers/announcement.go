@@ -14,15 +14,17 @@ import (
 )
 
 type AnnouncementReq struct {
-	Title   string `json:"title" binding:"required"`
-	Content string `json:"content" binding:"required"`
-	Scope   string `json:"scope"`
+	Title        string `json:"title" binding:"required"`
+	Content      string `json:"content" binding:"required"`
+	Scope        string `json:"scope"`
+	DepartmentID *uint  `json:"department_id"` // 部门公告，为空表示面向全社团；部门负责人必须指定自己负责的部门
 }
 
 // @Summary 获取社团公告列表
 // @Tags 负责人
 // @Produce json
 // @Param clubId path int true "社团ID"
+// @Param departmentId query int false "部门ID"
 // @Param page query int false "页码"
 // @Param pageSize query int false "每页数量"
 // @Security Bearer
@@ -37,13 +39,16 @@ func ListClubAnnouncements(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
-		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+	deptIDs, ok := departmentScope(c, u, uint(clubID), "departmentId", authz.PermPublishAnnouncements)
+	if !ok {
 		return
 	}
 
 	var list []models.Announcement
 	q := store.DB().Model(&models.Announcement{}).Where("club_id = ?", clubID).Order("id DESC")
+	if deptIDs != nil {
+		q = q.Where("department_id IN ?", deptIDs)
+	}
 	pg := pagination.Get(c)
 	info, err := pagination.Do(q, pg, &list)
 	if err != nil {
@@ -71,22 +76,31 @@ func CreateAnnouncement(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
-		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
-		return
-	}
 
 	var req AnnouncementReq
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
+	if !canManageDepartmentContent(u, uint(clubID), req.DepartmentID) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	if req.DepartmentID != nil {
+		var cnt int64
+		store.DB().Model(&models.Department{}).Where("id = ? AND club_id = ?", *req.DepartmentID, clubID).Count(&cnt)
+		if cnt == 0 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "部门不存在"))
+			return
+		}
+	}
 
 	ann := models.Announcement{
-		Title:   req.Title,
-		Content: req.Content,
-		Scope:   req.Scope,
-		ClubID:  uint(clubID),
+		Title:        req.Title,
+		Content:      req.Content,
+		Scope:        req.Scope,
+		ClubID:       uint(clubID),
+		DepartmentID: req.DepartmentID,
 	}
 	if ann.Scope == "" {
 		ann.Scope = "public"
@@ -121,10 +135,6 @@ func UpdateAnnouncement(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
-		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
-		return
-	}
 
 	var req AnnouncementReq
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -137,6 +147,11 @@ func UpdateAnnouncement(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "公告不存在"))
 		return
 	}
+	// 所属部门不随更新改变
+	if !canManageDepartmentContent(u, uint(clubID), ann.DepartmentID) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
 
 	updates := map[string]any{
 		"title":   req.Title,
@@ -173,12 +188,17 @@ func DeleteAnnouncement(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	var ann models.Announcement
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&ann).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "公告不存在"))
+		return
+	}
+	if !canManageDepartmentContent(u, uint(clubID), ann.DepartmentID) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
 
-	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).Delete(&models.Announcement{}).Error; err != nil {
+	if err := store.DB().Delete(&ann).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
 		return
 	}
@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationQuestionReq struct {
+	Title    string   `json:"title" binding:"required"`
+	Kind     string   `json:"kind"` // text, choice，默认 text
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+	Sort     int      `json:"sort"`
+}
+
+type ApplicationAnswerReq struct {
+	QuestionID uint   `json:"question_id"`
+	Answer     string `json:"answer"`
+}
+
+type ApplyReq struct {
+	Answers    []ApplicationAnswerReq `json:"answers"`
+	Attachment string                 `json:"attachment"` // 附件图片地址，通过 /upload/image 上传
+}
+
+func validateQuestion(q *models.ApplicationQuestion) string {
+	if q.Kind == "" {
+		q.Kind = "text"
+	}
+	switch q.Kind {
+	case "text":
+		q.Options = nil
+	case "choice":
+		opts := make([]string, 0, len(q.Options))
+		for _, o := range q.Options {
+			if o = strings.TrimSpace(o); o != "" {
+				opts = append(opts, o)
+			}
+		}
+		if len(opts) < 2 {
+			return "选择题至少需要两个选项"
+		}
+		q.Options = opts
+	default:
+		return "问题类型仅支持 text/choice"
+	}
+	if len([]rune(q.Title)) > 255 {
+		return "问题过长"
+	}
+	return ""
+}
+
+// buildAnswers 按社团当前问题校验申请回答，返回待保存的回答列表
+func buildAnswers(clubID uint, reqs []ApplicationAnswerReq) ([]models.ApplicationAnswer, string) {
+	var questions []models.ApplicationQuestion
+	store.DB().Where("club_id = ?", clubID).Order("sort ASC, id ASC").Find(&questions)
+	given := make(map[uint]string, len(reqs))
+	for _, a := range reqs {
+		given[a.QuestionID] = strings.TrimSpace(a.Answer)
+	}
+	answers := make([]models.ApplicationAnswer, 0, len(questions))
+	for _, q := range questions {
+		ans := given[q.ID]
+		if ans == "" {
+			if q.Required {
+				return nil, fmt.Sprintf("请回答：%s", q.Title)
+			}
+			continue
+		}
+		if q.Kind == "choice" {
+			valid := false
+			for _, o := range q.Options {
+				if o == ans {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, fmt.Sprintf("选项无效：%s", q.Title)
+			}
+		}
+		if len([]rune(ans)) > 2000 {
+			return nil, fmt.Sprintf("回答过长：%s", q.Title)
+		}
+		answers = append(answers, models.ApplicationAnswer{QuestionID: q.ID, Question: q.Title, Answer: ans})
+	}
+	return answers, ""
+}
+
+// @Summary 社团入社申请问题（公开）
+// @Tags 公共
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Success 200 {object} response.Body
+// @Router /public/clubs/{clubId}/questions [get]
+func ListApplicationQuestions(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var list []models.ApplicationQuestion
+	if err := store.DB().Where("club_id = ?", clubID).Order("sort ASC, id ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 新增入社申请问题（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body ApplicationQuestionReq true "问题"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/questions [post]
+func CreateApplicationQuestion(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req ApplicationQuestionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	q := models.ApplicationQuestion{ClubID: uint(clubID), Title: req.Title, Kind: req.Kind, Options: req.Options, Required: req.Required, Sort: req.Sort}
+	if msg := validateQuestion(&q); msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	if err := store.DB().Create(&q).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("新增入社申请问题: %s", q.Title), q.ClubID)
+	c.JSON(http.StatusOK, response.Success(q))
+}
+
+// @Summary 修改入社申请问题（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "问题ID"
+// @Param payload body ApplicationQuestionReq true "问题"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/questions/{id} [put]
+func UpdateApplicationQuestion(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req ApplicationQuestionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var q models.ApplicationQuestion
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&q).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "问题不存在"))
+		return
+	}
+	q.Title, q.Kind, q.Options, q.Required, q.Sort = req.Title, req.Kind, req.Options, req.Required, req.Sort
+	if msg := validateQuestion(&q); msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	if err := store.DB().Save(&q).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("修改入社申请问题 %d: %s", q.ID, q.Title), q.ClubID)
+	c.JSON(http.StatusOK, response.Success(q))
+}
+
+// @Summary 删除入社申请问题（负责人，已提交的回答保留）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "问题ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/questions/{id} [delete]
+func DeleteApplicationQuestion(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var q models.ApplicationQuestion
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&q).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "问题不存在"))
+		return
+	}
+	if err := store.DB().Delete(&q).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("删除入社申请问题 %d: %s", q.ID, q.Title), q.ClubID)
+	c.JSON(http.StatusOK, response.Success(nil))
+}
@@ -2,11 +2,12 @@ package controllers
 
 import (
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 	"web_server/db/models"
+	"web_server/internal/attendance"
 	"web_server/internal/authz"
 	"web_server/internal/store"
 	"web_server/pkg/pagination"
@@ -15,10 +16,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+type SignInReq struct {
+	Type string `json:"type"` // 考勤类型编码，不传则继承活动类型
+}
+
 // @Summary 会员签到
 // @Tags 考勤
+// @Accept json
 // @Produce json
 // @Param activityId path int true "活动ID"
+// @Param payload body SignInReq false "考勤类型"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /member/activities/{activityId}/signin [post]
@@ -29,34 +36,13 @@ func SignIn(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
-	var act models.Activity
-	if err := store.DB().Where("id = ?", activityID).First(&act).Error; err != nil {
-		c.JSON(http.StatusNotFound, response.Error(404, "活动不存在"))
-		return
-	}
+	var req SignInReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !authz.IsClubMember(u.ID, act.ClubID) {
-		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
-		return
-	}
-	// 必须先报名
-	var reg models.ActivityParticipant
-	if err := store.DB().Where("user_id = ? AND activity_id = ? AND club_id = ? AND status = ?", u.ID, activityID, act.ClubID, "confirmed").First(&reg).Error; err != nil {
-		c.JSON(http.StatusBadRequest, response.Error(400, "需先报名该活动"))
-		return
-	}
-	// 检查是否已有未签退的签到记录
-	var latest models.Attendance
-	if err := store.DB().Where("user_id = ? AND club_id = ? AND activity_id = ? AND signout_at IS NULL", u.ID, act.ClubID, activityID).Order("id DESC").First(&latest).Error; err == nil {
-		c.JSON(http.StatusBadRequest, response.Error(400, "已签到，未签退"))
-		return
-	}
-	now := time.Now()
-	aid := uint(activityID)
-	att := models.Attendance{UserID: u.ID, ActivityID: &aid, ClubID: act.ClubID, SigninAt: &now}
-	if err := store.DB().Create(&att).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(500, "签到失败"))
+	att, e := signInActivity(u, uint(activityID), req.Type, time.Now())
+	if e != nil {
+		c.JSON(e.Status, response.Error(e.Status, e.Msg))
 		return
 	}
 	c.JSON(http.StatusOK, response.Success(att))
@@ -64,8 +50,10 @@ func SignIn(c *gin.Context) {
 
 // @Summary 社团签到（与活动无关）
 // @Tags 考勤
+// @Accept json
 // @Produce json
 // @Param clubId path int true "社团ID"
+// @Param payload body SignInReq false "考勤类型"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /member/clubs/{clubId}/signin [post]
@@ -76,26 +64,13 @@ func ClubSignIn(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
-	var cl models.Club
-	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
-		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
-		return
-	}
+	var req SignInReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !authz.IsClubMember(u.ID, cl.ID) {
-		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
-		return
-	}
-	var latest models.Attendance
-	if err := store.DB().Where("user_id = ? AND club_id = ? AND signout_at IS NULL", u.ID, clubID).Order("id DESC").First(&latest).Error; err == nil {
-		c.JSON(http.StatusBadRequest, response.Error(400, "已签到，未签退"))
-		return
-	}
-	now := time.Now()
-	att := models.Attendance{UserID: u.ID, ActivityID: nil, ClubID: uint(clubID), SigninAt: &now}
-	if err := store.DB().Create(&att).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(500, "签到失败"))
+	att, e := signInClub(u, uint(clubID), req.Type, time.Now())
+	if e != nil {
+		c.JSON(e.Status, response.Error(e.Status, e.Msg))
 		return
 	}
 	c.JSON(http.StatusOK, response.Success(att))
@@ -115,43 +90,18 @@ func ClubSignOut(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
-	var cl models.Club
-	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
-		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
-		return
-	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !authz.IsClubMember(u.ID, cl.ID) {
-		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
+	att, counted, e := signOutClub(u, uint(clubID), time.Now())
+	if e != nil {
+		c.JSON(e.Status, response.Error(e.Status, e.Msg))
 		return
 	}
-	var latest models.Attendance
-	if err := store.DB().Where("user_id = ? AND club_id = ? AND signout_at IS NULL", u.ID, clubID).Order("id DESC").First(&latest).Error; err != nil {
-		c.JSON(http.StatusBadRequest, response.Error(400, "未找到签到记录"))
+	if !counted {
+		c.JSON(http.StatusOK, response.Success(nil))
 		return
 	}
-	now := time.Now()
-	if latest.SigninAt != nil {
-		d := now.Sub(*latest.SigninAt)
-		if d < time.Minute {
-			if err := store.DB().Delete(&latest).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, response.Error(500, "签退失败"))
-				return
-			}
-			c.JSON(http.StatusOK, response.Success(nil))
-			return
-		}
-		latest.DurationMinutes = int(d.Minutes())
-		hours := d.Hours()
-		latest.DurationHours = math.Round(hours*100) / 100
-	}
-	latest.SignoutAt = &now
-	if err := store.DB().Save(&latest).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(500, "签退失败"))
-		return
-	}
-	c.JSON(http.StatusOK, response.Success(latest))
+	c.JSON(http.StatusOK, response.Success(att))
 }
 
 // @Summary 会员签退
@@ -168,44 +118,18 @@ func SignOut(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
-	var act models.Activity
-	if err := store.DB().Where("id = ?", activityID).First(&act).Error; err != nil {
-		c.JSON(http.StatusNotFound, response.Error(404, "活动不存在"))
-		return
-	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !authz.IsClubMember(u.ID, act.ClubID) {
-		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
-		return
-	}
-	// 查找最近一次未签退的签到记录并更新为签退
-	var latest models.Attendance
-	if err := store.DB().Where("user_id = ? AND club_id = ? AND activity_id = ? AND signout_at IS NULL", u.ID, act.ClubID, activityID).Order("id DESC").First(&latest).Error; err != nil {
-		c.JSON(http.StatusBadRequest, response.Error(400, "未找到签到记录"))
+	att, counted, e := signOutActivity(u, uint(activityID), time.Now())
+	if e != nil {
+		c.JSON(e.Status, response.Error(e.Status, e.Msg))
 		return
 	}
-	now := time.Now()
-	if latest.SigninAt != nil {
-		d := now.Sub(*latest.SigninAt)
-		if d < time.Minute {
-			if err := store.DB().Delete(&latest).Error; err != nil {
-				c.JSON(http.StatusInternalServerError, response.Error(500, "签退失败"))
-				return
-			}
-			c.JSON(http.StatusOK, response.Success(nil))
-			return
-		}
-		latest.DurationMinutes = int(d.Minutes())
-		hours := d.Hours()
-		latest.DurationHours = math.Round(hours*100) / 100
-	}
-	latest.SignoutAt = &now
-	if err := store.DB().Save(&latest).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, response.Error(500, "签退失败"))
+	if !counted {
+		c.JSON(http.StatusOK, response.Success(nil))
 		return
 	}
-	c.JSON(http.StatusOK, response.Success(latest))
+	c.JSON(http.StatusOK, response.Success(att))
 }
 
 // @Summary 报名参加活动
@@ -233,6 +157,10 @@ func RegisterActivity(c *gin.Context) {
 		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
 		return
 	}
+	if duesBlocked(act.ClubID, u.ID, time.Now()) {
+		c.JSON(http.StatusForbidden, response.Error(403, "本期社费未缴清，暂不能报名活动"))
+		return
+	}
 	// 已报名则返回成功
 	var exist models.ActivityParticipant
 	if err := store.DB().Where("user_id = ? AND activity_id = ? AND club_id = ?", u.ID, activityID, act.ClubID).First(&exist).Error; err == nil {
@@ -334,6 +262,8 @@ func CancelRegisterActivity(c *gin.Context) {
 // @Tags 考勤
 // @Produce json
 // @Param clubId query int true "社团ID"
+// @Param termId query string false "学期ID，默认当前学期，all 表示不限学期"
+// @Param type query string false "考勤类型编码"
 // @Param page query int false "页码"
 // @Param pageSize query int false "每页数量"
 // @Security Bearer
@@ -353,7 +283,18 @@ func MyAttendance(c *gin.Context) {
 		return
 	}
 	var list []models.Attendance
-	q := store.DB().Model(&models.Attendance{}).Where("user_id = ? AND club_id = ?", u.ID, clubID).Preload("Activity").Order("id DESC")
+	term, ok := termFilter(c, "termId")
+	if !ok {
+		return
+	}
+	q := store.DB().Model(&models.Attendance{}).Scopes(attendance.Valid).Where("user_id = ? AND club_id = ?", u.ID, clubID).Preload("Activity").Order("id DESC")
+	if term != nil {
+		start, end := termBounds(term)
+		q = q.Where("signin_at BETWEEN ? AND ?", start, end)
+	}
+	if typ := c.Query("type"); typ != "" {
+		q = q.Where("type = ?", typ)
+	}
 	pg := pagination.Get(c)
 	info, err := pagination.Do(q, pg, &list)
 	if err != nil {
@@ -363,15 +304,18 @@ func MyAttendance(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
 }
 
-// @Summary 社团考勤检索（负责人）
+// @Summary 社团考勤检索（负责人/部门负责人）
 // @Tags 考勤
 // @Produce json
 // @Param clubId path int true "社团ID"
+// @Param departmentId query int false "部门ID，部门负责人仅可查看自己负责的部门"
 // @Param userId query int false "用户ID"
+// @Param termId query string false "学期ID，默认当前学期，all 表示不限学期"
+// @Param type query string false "考勤类型编码"
 // @Param page query int false "页码"
 // @Param pageSize query int false "每页数量"
 // @Security Bearer
-// @Success 200 {object} response.Body
+// @Success 200 {object} response.Body "list 为考勤记录，leaves 为同条件下已批准的请假"
 // @Router /leader/clubs/{clubId}/attendance [get]
 func ClubAttendance(c *gin.Context) {
 	clubIDStr := c.Param("clubId")
@@ -382,16 +326,34 @@ func ClubAttendance(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
-		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+	deptIDs, ok := departmentScope(c, u, uint(clubID), "departmentId", authz.PermManageAttendance)
+	if !ok {
 		return
 	}
-	q := store.DB().Where("club_id = ?", clubID)
+	q := store.DB().Model(&models.Attendance{}).Scopes(attendance.Valid).Where("club_id = ?", clubID)
+	lq := store.DB().Model(&models.LeaveRequest{}).Where("club_id = ?", clubID)
+	if deptIDs != nil {
+		q = q.Where("user_id IN (?)", departmentUsers(deptIDs))
+		lq = lq.Where("user_id IN (?)", departmentUsers(deptIDs))
+	}
 	if uidStr := c.Query("userId"); uidStr != "" {
 		if uid, e := strconv.Atoi(uidStr); e == nil && uid > 0 {
 			q = q.Where("user_id = ?", uid)
+			lq = lq.Where("user_id = ?", uid)
 		}
 	}
+	term, ok := termFilter(c, "termId")
+	if !ok {
+		return
+	}
+	if term != nil {
+		start, end := termBounds(term)
+		q = q.Where("signin_at BETWEEN ? AND ?", start, end)
+		lq = lq.Where("leave_date BETWEEN ? AND ?", start, end)
+	}
+	if typ := c.Query("type"); typ != "" {
+		q = q.Where("type = ?", typ)
+	}
 	var list []models.Attendance
 	pg := pagination.Get(c)
 	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
@@ -399,13 +361,21 @@ func ClubAttendance(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
 		return
 	}
-	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+	// 已批准的请假与考勤一并返回
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info, "leaves": approvedLeaves(lq)}))
+}
+
+type DeleteAttendanceReq struct {
+	Reason string `json:"reason"`
 }
 
-// @Summary 删除异常考勤记录（负责人）
+// @Summary 作废考勤记录（负责人）
 // @Tags 考勤
+// @Accept json
 // @Produce json
 // @Param id path int true "考勤记录ID"
+// @Param reason query string false "作废原因（也可通过请求体传递）"
+// @Param payload body DeleteAttendanceReq false "作废原因"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /leader/attendance/{id} [delete]
@@ -416,6 +386,16 @@ func DeleteAttendance(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
+	reason := strings.TrimSpace(c.Query("reason"))
+	if reason == "" {
+		var req DeleteAttendanceReq
+		_ = c.ShouldBindJSON(&req)
+		reason = strings.TrimSpace(req.Reason)
+	}
+	if reason == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "请填写作废原因"))
+		return
+	}
 	var att models.Attendance
 	if err := store.DB().Where("id = ?", id).First(&att).Error; err != nil {
 		c.JSON(http.StatusNotFound, response.Error(404, "不存在"))
@@ -423,18 +403,104 @@ func DeleteAttendance(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, att.ClubID)) {
+	if !canManageMemberAttendance(u, &att) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
-	if err := store.DB().Delete(&att).Error; err != nil {
+	if att.VoidedAt != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "该记录已作废"))
+		return
+	}
+	// 软删除：保留原始签到数据，统计与列表默认不再计入
+	if err := attendance.Void(store.DB(), &att, u.ID, reason); err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
 		return
 	}
-	RecordLog(u.ID, u.Name, "修改打卡", fmt.Sprintf("删除考勤记录 %d", id), att.ClubID)
+	RecordLog(u.ID, u.Name, "修改打卡", fmt.Sprintf("作废考勤记录 %d（用户 %d，时长 %.2f 小时），原因：%s", id, att.UserID, att.DurationHours, reason), att.ClubID)
 	c.JSON(http.StatusOK, response.Success(nil))
 }
 
+// @Summary 已作废考勤记录（负责人/部门负责人）
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param departmentId query int false "部门ID"
+// @Param userId query int false "用户ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/attendance/voided [get]
+func ListVoidedAttendance(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	deptIDs, ok := departmentScope(c, u, uint(clubID), "departmentId", authz.PermManageAttendance)
+	if !ok {
+		return
+	}
+	q := store.DB().Model(&models.Attendance{}).Where("club_id = ? AND voided_at IS NOT NULL", clubID).Preload("User").Preload("Activity")
+	if deptIDs != nil {
+		q = q.Where("user_id IN (?)", departmentUsers(deptIDs))
+	}
+	if uidStr := c.Query("userId"); uidStr != "" {
+		if uid, e := strconv.Atoi(uidStr); e == nil && uid > 0 {
+			q = q.Where("user_id = ?", uid)
+		}
+	}
+	var list []models.Attendance
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("voided_at DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 恢复已作废考勤记录（负责人）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "考勤记录ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/attendance/{id}/restore [post]
+func RestoreAttendance(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var att models.Attendance
+	if err := store.DB().Where("id = ?", id).First(&att).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !canManageMemberAttendance(u, &att) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	reason := att.VoidReason
+	if err := attendance.Restore(store.DB(), &att); err != nil {
+		if err == attendance.ErrNotVoided {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "恢复失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改打卡", fmt.Sprintf("恢复考勤记录 %d（原作废原因：%s）", id, reason), att.ClubID)
+	c.JSON(http.StatusOK, response.Success(att))
+}
+
 // @Summary 强制签退（负责人）
 // @Tags 考勤
 // @Produce json
@@ -456,7 +522,7 @@ func ForceSignOut(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, att.ClubID)) {
+	if !canManageMemberAttendance(u, &att) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -464,18 +530,16 @@ func ForceSignOut(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "该记录已签退"))
 		return
 	}
-	now := time.Now()
-	if att.SigninAt != nil {
-		d := now.Sub(*att.SigninAt)
-		att.DurationMinutes = int(d.Minutes())
-		hours := d.Hours()
-		att.DurationHours = math.Round(hours*100) / 100
-	}
-	att.SignoutAt = &now
-	if err := store.DB().Save(&att).Error; err != nil {
+	counted := attendance.Finish(&att, time.Now())
+	if err := attendance.SaveSettled(store.DB(), &att, counted, u.ID); err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "强制签退失败"))
 		return
 	}
+	if !counted {
+		RecordLog(u.ID, u.Name, "修改打卡", fmt.Sprintf("强制签退考勤记录 %d，时长不足已作废", id), att.ClubID)
+		c.JSON(http.StatusOK, response.Success(nil))
+		return
+	}
 	RecordLog(u.ID, u.Name, "修改打卡", fmt.Sprintf("强制签退考勤记录 %d", id), att.ClubID)
 	c.JSON(http.StatusOK, response.Success(att))
 }
@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/config"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary 扫描考勤异常（负责人/管理员）
+// @Tags 考勤
+// @Produce json
+// @Param club_id query int false "社团ID"
+// @Param days query int false "扫描最近天数，默认30"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/attendance/anomalies/scan [post]
+func ScanAttendanceAnomalies(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	clubIDs, all, allowed, err := managedClubScope(u, c.Query("club_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限查看该社团考勤"))
+		return
+	}
+	if !all && len(clubIDs) == 0 {
+		c.JSON(http.StatusOK, response.Success(map[string]any{"found": 0, "created": 0}))
+		return
+	}
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if days <= 0 {
+		days = 30
+	}
+	now := time.Now()
+	flags, err := attendance.Analyze(clubIDs, now.AddDate(0, 0, -days), config.Default().Attendance, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "扫描失败"))
+		return
+	}
+	created, err := attendance.SaveFlags(flags)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "保存失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"found": len(flags), "created": created}))
+}
+
+// @Summary 考勤异常审核队列（负责人/管理员）
+// @Tags 考勤
+// @Produce json
+// @Param club_id query int false "社团ID"
+// @Param status query string false "状态: pending/accepted/voided，默认pending"
+// @Param kind query string false "类型: overlap/long_session/burst/outside_window"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/attendance/anomalies [get]
+// @Router /admin/attendance/anomalies [get]
+func ListAttendanceAnomalies(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	clubIDs, all, allowed, err := managedClubScope(u, c.Query("club_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限查看该社团考勤"))
+		return
+	}
+	q := store.DB().Model(&models.AttendanceFlag{}).Preload("Attendance").Preload("User").Preload("Club")
+	if !all {
+		q = q.Where("club_id IN ?", clubIDs)
+	}
+	q = q.Where("status = ?", c.DefaultQuery("status", "pending"))
+	if kind := c.Query("kind"); kind != "" {
+		q = q.Where("kind = ?", kind)
+	}
+	var list []models.AttendanceFlag
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 确认考勤异常记录有效（负责人/管理员）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "异常标记ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/attendance/anomalies/{id}/accept [post]
+func AcceptAttendanceAnomaly(c *gin.Context) {
+	reviewAttendanceAnomaly(c, "accepted")
+}
+
+// @Summary 作废异常考勤记录（负责人/管理员）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "异常标记ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/attendance/anomalies/{id}/void [post]
+func VoidAttendanceAnomaly(c *gin.Context) {
+	reviewAttendanceAnomaly(c, "voided")
+}
+
+func reviewAttendanceAnomaly(c *gin.Context, status string) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var flag models.AttendanceFlag
+	if err := store.DB().Where("id = ?", id).First(&flag).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, flag.ClubID, authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	if flag.Status != "pending" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "该异常已处理"))
+		return
+	}
+	now := time.Now()
+	tx := store.DB().Begin()
+	if status == "voided" {
+		// 作废考勤记录，同一记录的其他待审核异常一并作废
+		var att models.Attendance
+		if err := tx.Where("id = ?", flag.AttendanceID).First(&att).Error; err == nil && att.VoidedAt == nil {
+			if err := attendance.Void(tx, &att, u.ID, "异常审核作废："+flag.Kind); err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+				return
+			}
+		}
+		if err := tx.Model(&models.AttendanceFlag{}).Where("attendance_id = ? AND status = ?", flag.AttendanceID, "pending").
+			Updates(map[string]any{"status": "voided", "reviewer_id": u.ID, "reviewed_at": now}).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+			return
+		}
+	} else if err := tx.Model(&flag).Updates(map[string]any{"status": status, "reviewer_id": u.ID, "reviewed_at": now}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	tx.Commit()
+
+	action := "确认"
+	if status == "voided" {
+		action = "作废"
+	}
+	RecordLog(u.ID, u.Name, "修改打卡", fmt.Sprintf("%s异常考勤记录 %d（%s）", action, flag.AttendanceID, flag.Kind), flag.ClubID)
+	flag.Status = status
+	flag.ReviewerID = u.ID
+	flag.ReviewedAt = &now
+	c.JSON(http.StatusOK, response.Success(flag))
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+)
+
+// attendanceError 签到/签退业务错误，携带对应的HTTP状态码
+type attendanceError struct {
+	Status int
+	Msg    string
+}
+
+func (e *attendanceError) Error() string { return e.Msg }
+
+func attErr(status int, msg string) *attendanceError {
+	return &attendanceError{Status: status, Msg: msg}
+}
+
+// signInActivity 活动签到规则，在线签到与离线补传共用
+func signInActivity(u *models.User, activityID uint, typ string, at time.Time) (*models.Attendance, *attendanceError) {
+	var act models.Activity
+	if err := store.DB().Where("id = ?", activityID).First(&act).Error; err != nil {
+		return nil, attErr(http.StatusNotFound, "活动不存在")
+	}
+	if !authz.IsClubMember(u.ID, act.ClubID) {
+		return nil, attErr(http.StatusForbidden, "非社团成员")
+	}
+	// 必须先报名
+	var reg models.ActivityParticipant
+	if err := store.DB().Where("user_id = ? AND activity_id = ? AND club_id = ? AND status = ?", u.ID, activityID, act.ClubID, "confirmed").First(&reg).Error; err != nil {
+		return nil, attErr(http.StatusBadRequest, "需先报名该活动")
+	}
+	// 检查是否已有未签退的签到记录
+	var latest models.Attendance
+	if err := store.DB().Scopes(attendance.Valid).Where("user_id = ? AND club_id = ? AND activity_id = ? AND signout_at IS NULL", u.ID, act.ClubID, activityID).Order("id DESC").First(&latest).Error; err == nil {
+		return nil, attErr(http.StatusBadRequest, "已签到，未签退")
+	}
+	if err := attendance.CheckSignIn(attendance.CurrentPolicy(act.ClubID), at); err != nil {
+		return nil, attErr(http.StatusBadRequest, err.Error())
+	}
+	st, err := attendance.ResolveType(act.ClubID, typ, &act)
+	if err != nil {
+		return nil, attErr(http.StatusBadRequest, err.Error())
+	}
+	att := models.Attendance{UserID: u.ID, ActivityID: &activityID, ClubID: act.ClubID, Type: st.Code, Ledger: st.Ledger, SigninAt: &at}
+	if err := store.DB().Create(&att).Error; err != nil {
+		return nil, attErr(http.StatusInternalServerError, "签到失败")
+	}
+	return &att, nil
+}
+
+// signInClub 社团签到规则（与活动无关），在线签到与离线补传共用
+func signInClub(u *models.User, clubID uint, typ string, at time.Time) (*models.Attendance, *attendanceError) {
+	var cl models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
+		return nil, attErr(http.StatusNotFound, "社团不存在")
+	}
+	if !authz.IsClubMember(u.ID, cl.ID) {
+		return nil, attErr(http.StatusForbidden, "非社团成员")
+	}
+	var latest models.Attendance
+	if err := store.DB().Scopes(attendance.Valid).Where("user_id = ? AND club_id = ? AND signout_at IS NULL", u.ID, clubID).Order("id DESC").First(&latest).Error; err == nil {
+		return nil, attErr(http.StatusBadRequest, "已签到，未签退")
+	}
+	if err := attendance.CheckSignIn(attendance.CurrentPolicy(cl.ID), at); err != nil {
+		return nil, attErr(http.StatusBadRequest, err.Error())
+	}
+	st, err := attendance.ResolveType(cl.ID, typ, nil)
+	if err != nil {
+		return nil, attErr(http.StatusBadRequest, err.Error())
+	}
+	att := models.Attendance{UserID: u.ID, ActivityID: nil, ClubID: clubID, Type: st.Code, Ledger: st.Ledger, SigninAt: &at}
+	if err := store.DB().Create(&att).Error; err != nil {
+		return nil, attErr(http.StatusInternalServerError, "签到失败")
+	}
+	return &att, nil
+}
+
+// signOutActivity 活动签退规则，返回的 counted 为 false 表示时长不足已作废
+func signOutActivity(u *models.User, activityID uint, at time.Time) (*models.Attendance, bool, *attendanceError) {
+	var act models.Activity
+	if err := store.DB().Where("id = ?", activityID).First(&act).Error; err != nil {
+		return nil, false, attErr(http.StatusNotFound, "活动不存在")
+	}
+	if !authz.IsClubMember(u.ID, act.ClubID) {
+		return nil, false, attErr(http.StatusForbidden, "非社团成员")
+	}
+	// 查找最近一次未签退的签到记录并更新为签退
+	var latest models.Attendance
+	if err := store.DB().Scopes(attendance.Valid).Where("user_id = ? AND club_id = ? AND activity_id = ? AND signout_at IS NULL", u.ID, act.ClubID, activityID).Order("id DESC").First(&latest).Error; err != nil {
+		return nil, false, attErr(http.StatusBadRequest, "未找到签到记录")
+	}
+	return settleSignOut(&latest, at)
+}
+
+// signOutClub 社团签退规则（与活动无关），返回值含义同 signOutActivity
+func signOutClub(u *models.User, clubID uint, at time.Time) (*models.Attendance, bool, *attendanceError) {
+	var cl models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
+		return nil, false, attErr(http.StatusNotFound, "社团不存在")
+	}
+	if !authz.IsClubMember(u.ID, cl.ID) {
+		return nil, false, attErr(http.StatusForbidden, "非社团成员")
+	}
+	var latest models.Attendance
+	if err := store.DB().Scopes(attendance.Valid).Where("user_id = ? AND club_id = ? AND signout_at IS NULL", u.ID, clubID).Order("id DESC").First(&latest).Error; err != nil {
+		return nil, false, attErr(http.StatusBadRequest, "未找到签到记录")
+	}
+	return settleSignOut(&latest, at)
+}
+
+// settleSignOut 按社团考勤规则结算，时长不足的记录作废保留，不计入时长
+func settleSignOut(att *models.Attendance, at time.Time) (*models.Attendance, bool, *attendanceError) {
+	if att.SigninAt != nil && at.Before(*att.SigninAt) {
+		return nil, false, attErr(http.StatusBadRequest, "签退时间早于签到时间")
+	}
+	counted := attendance.Finish(att, at)
+	if err := attendance.SaveSettled(store.DB(), att, counted, 0); err != nil {
+		return nil, false, attErr(http.StatusInternalServerError, "签退失败")
+	}
+	return att, counted, nil
+}
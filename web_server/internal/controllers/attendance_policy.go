@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AttendancePolicyReq struct {
+	MinMinutes      int    `json:"min_minutes"`
+	MaxMinutes      int    `json:"max_minutes"`
+	RoundingMinutes int    `json:"rounding_minutes"`
+	RoundingMode    string `json:"rounding_mode"`
+	SigninStart     string `json:"signin_start"`
+	SigninEnd       string `json:"signin_end"`
+}
+
+// @Summary 获取社团考勤规则（负责人）
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/attendance/policy [get]
+func GetAttendancePolicy(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var history []models.AttendancePolicy
+	if err := store.DB().Where("club_id = ?", clubID).Order("version DESC").Find(&history).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{
+		"current": attendance.CurrentPolicy(uint(clubID)),
+		"history": history,
+	}))
+}
+
+// @Summary 修改社团考勤规则（负责人）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body AttendancePolicyReq true "考勤规则"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/attendance/policy [put]
+func UpdateAttendancePolicy(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req AttendancePolicyReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.RoundingMode == "" {
+		req.RoundingMode = "nearest"
+	}
+	p := models.AttendancePolicy{
+		ClubID:          uint(clubID),
+		Version:         attendance.CurrentPolicy(uint(clubID)).Version + 1,
+		MinMinutes:      req.MinMinutes,
+		MaxMinutes:      req.MaxMinutes,
+		RoundingMinutes: req.RoundingMinutes,
+		RoundingMode:    req.RoundingMode,
+		SigninStart:     req.SigninStart,
+		SigninEnd:       req.SigninEnd,
+		CreatedBy:       u.ID,
+	}
+	if err := attendance.Validate(p); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+		return
+	}
+	// 规则只追加新版本，历史记录通过 policy_version 追溯
+	if err := store.DB().Create(&p).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "保存失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("更新考勤规则为版本 %d", p.Version), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(p))
+}
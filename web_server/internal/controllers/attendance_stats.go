@@ -0,0 +1,456 @@
+package controllers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statRow 统计所需的考勤字段
+type statRow struct {
+	UserID          uint
+	ClubID          uint
+	ActivityID      *uint
+	SigninAt        *time.Time
+	Type            string
+	Ledger          string
+	DurationMinutes int
+	DurationHours   float64
+	WeightedHours   float64
+}
+
+type statKey struct {
+	ClubID uint
+	UserID uint
+}
+
+type MemberStat struct {
+	UserID             uint    `json:"user_id"`
+	UserName           string  `json:"user_name"`
+	StudentNo          string  `json:"student_no"`
+	ClubID             uint    `json:"club_id"`
+	ClubName           string  `json:"club_name"`
+	Sessions           int     `json:"sessions"`
+	TotalMinutes       int     `json:"total_minutes"`
+	TotalHours         float64 `json:"total_hours"`
+	WeightedHours      float64 `json:"weighted_hours"`
+	AvgSessionMinutes  float64 `json:"avg_session_minutes"`
+	Rank               int     `json:"rank"`
+	LongestStreakWeeks int     `json:"longest_streak_weeks"`
+	CurrentStreakWeeks int     `json:"current_streak_weeks"`
+
+	weeks map[string]bool
+}
+
+// TypeStat 社团内按考勤类型汇总
+type TypeStat struct {
+	ClubID        uint    `json:"club_id"`
+	Type          string  `json:"type"`
+	Sessions      int     `json:"sessions"`
+	Hours         float64 `json:"hours"`
+	WeightedHours float64 `json:"weighted_hours"`
+}
+
+// LedgerStat 成员计入各台账的加权时长
+type LedgerStat struct {
+	UserID uint    `json:"user_id"`
+	ClubID uint    `json:"club_id"`
+	Ledger string  `json:"ledger"`
+	Hours  float64 `json:"hours"`
+}
+
+type PeriodStat struct {
+	UserID   uint    `json:"user_id"`
+	ClubID   uint    `json:"club_id"`
+	Period   string  `json:"period"`
+	Sessions int     `json:"sessions"`
+	Hours    float64 `json:"hours"`
+}
+
+// @Summary 考勤统计（按成员/社团/月/周汇总）
+// @Tags 考勤
+// @Produce json
+// @Param club_id query int false "社团ID"
+// @Param user_id query int false "用户ID"
+// @Param start_date query string false "开始日期(YYYY-MM-DD)"
+// @Param end_date query string false "结束日期(YYYY-MM-DD)"
+// @Param source query string false "来源: activity(活动签到)/club(社团签到)"
+// @Param term_id query string false "学期ID，默认当前学期，all 表示不限学期"
+// @Param type query string false "考勤类型编码"
+// @Param group_by query string false "附加分组: term(按学期汇总)"
+// @Param department_id query int false "部门ID，部门负责人可查看本部门"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/attendance/stats [get]
+// @Router /admin/attendance/stats [get]
+func AttendanceStats(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+
+	clubIDs, all, dept, ok := departmentReportScope(c, u)
+	if !ok {
+		return
+	}
+	if !all && len(clubIDs) == 0 {
+		c.JSON(http.StatusOK, response.Success(map[string]any{
+			"members": []MemberStat{},
+			"monthly": []PeriodStat{},
+			"weekly":  []PeriodStat{},
+			"by_type": []TypeStat{},
+			"ledgers": []LedgerStat{},
+		}))
+		return
+	}
+
+	// 只统计已签退且未作废的记录
+	db := store.DB().Model(&models.Attendance{}).Scopes(attendance.Valid).Where("attendances.signout_at IS NOT NULL")
+	if !all {
+		db = db.Where("attendances.club_id IN ?", clubIDs)
+	}
+	if dept != nil {
+		db = db.Where("attendances.user_id IN (?)", departmentUsers([]uint{dept.ID}))
+	}
+	if uidStr := c.Query("user_id"); uidStr != "" {
+		if uid, e := strconv.Atoi(uidStr); e == nil && uid > 0 {
+			db = db.Where("attendances.user_id = ?", uid)
+		}
+	}
+	if start := c.Query("start_date"); start != "" {
+		db = db.Where("attendances.signin_at >= ?", start+" 00:00:00")
+	}
+	if end := c.Query("end_date"); end != "" {
+		db = db.Where("attendances.signin_at <= ?", end+" 23:59:59")
+	}
+	if typ := c.Query("type"); typ != "" {
+		db = db.Where("attendances.type = ?", typ)
+	}
+	term, ok := termFilter(c, "term_id")
+	if !ok {
+		return
+	}
+	if term != nil {
+		start, end := termBounds(term)
+		db = db.Where("attendances.signin_at BETWEEN ? AND ?", start, end)
+	}
+	switch c.Query("source") {
+	case "":
+	case "activity":
+		db = db.Where("attendances.activity_id IS NOT NULL")
+	case "club":
+		db = db.Where("attendances.activity_id IS NULL")
+	default:
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+
+	var rows []statRow
+	if err := db.Select("attendances.user_id, attendances.club_id, attendances.activity_id, attendances.signin_at, attendances.type, attendances.ledger, attendances.duration_minutes, attendances.duration_hours, attendances.weighted_hours").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+
+	members, monthly, weekly := aggregateAttendance(rows, time.Now())
+	fillStatNames(members)
+	res := map[string]any{
+		"members": members,
+		"monthly": monthly,
+		"weekly":  weekly,
+		"by_type": aggregateByType(rows),
+		"ledgers": aggregateLedgers(rows),
+	}
+	if c.Query("group_by") == "term" {
+		var terms []models.Term
+		store.DB().Order("start_date ASC").Find(&terms)
+		res["termly"] = aggregateByTerm(rows, terms)
+	}
+	c.JSON(http.StatusOK, response.Success(res))
+}
+
+// aggregateByType 按社团与考勤类型汇总，未设置类型的记录归入空类型
+func aggregateByType(rows []statRow) []TypeStat {
+	type key struct {
+		clubID uint
+		typ    string
+	}
+	m := map[key]*TypeStat{}
+	for _, r := range rows {
+		k := key{r.ClubID, r.Type}
+		ts := m[k]
+		if ts == nil {
+			ts = &TypeStat{ClubID: r.ClubID, Type: r.Type}
+			m[k] = ts
+		}
+		ts.Sessions++
+		ts.Hours += r.DurationHours
+		ts.WeightedHours += r.WeightedHours
+	}
+	list := make([]TypeStat, 0, len(m))
+	for _, ts := range m {
+		ts.Hours = round2(ts.Hours)
+		ts.WeightedHours = round2(ts.WeightedHours)
+		list = append(list, *ts)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ClubID != list[j].ClubID {
+			return list[i].ClubID < list[j].ClubID
+		}
+		return list[i].Type < list[j].Type
+	})
+	return list
+}
+
+// aggregateLedgers 汇总成员计入志愿时长、第二课堂等台账的加权时长
+func aggregateLedgers(rows []statRow) []LedgerStat {
+	type key struct {
+		statKey
+		ledger string
+	}
+	m := map[key]*LedgerStat{}
+	for _, r := range rows {
+		if r.Ledger == "" {
+			continue
+		}
+		k := key{statKey{ClubID: r.ClubID, UserID: r.UserID}, r.Ledger}
+		ls := m[k]
+		if ls == nil {
+			ls = &LedgerStat{UserID: r.UserID, ClubID: r.ClubID, Ledger: r.Ledger}
+			m[k] = ls
+		}
+		ls.Hours += r.WeightedHours
+	}
+	list := make([]LedgerStat, 0, len(m))
+	for _, ls := range m {
+		ls.Hours = round2(ls.Hours)
+		list = append(list, *ls)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ClubID != list[j].ClubID {
+			return list[i].ClubID < list[j].ClubID
+		}
+		if list[i].UserID != list[j].UserID {
+			return list[i].UserID < list[j].UserID
+		}
+		return list[i].Ledger < list[j].Ledger
+	})
+	return list
+}
+
+// aggregateByTerm 按学期汇总每位成员的考勤，不在任何学期内的记录不计入
+func aggregateByTerm(rows []statRow, terms []models.Term) []PeriodStat {
+	m := map[statKey]map[string]*PeriodStat{}
+	for _, r := range rows {
+		if r.SigninAt == nil {
+			continue
+		}
+		t := termOf(terms, *r.SigninAt)
+		if t == nil {
+			continue
+		}
+		k := statKey{ClubID: r.ClubID, UserID: r.UserID}
+		if m[k] == nil {
+			m[k] = map[string]*PeriodStat{}
+		}
+		p := m[k][t.Name]
+		if p == nil {
+			p = &PeriodStat{UserID: r.UserID, ClubID: r.ClubID, Period: t.Name}
+			m[k][t.Name] = p
+		}
+		p.Sessions++
+		p.Hours += r.DurationHours
+	}
+	return flattenPeriods(m)
+}
+
+// aggregateAttendance 按成员、月份、周汇总考勤，并计算社团内排名与连续出勤周数
+func aggregateAttendance(rows []statRow, now time.Time) ([]MemberStat, []PeriodStat, []PeriodStat) {
+	memberMap := map[statKey]*MemberStat{}
+	monthMap := map[statKey]map[string]*PeriodStat{}
+	weekMap := map[statKey]map[string]*PeriodStat{}
+
+	addPeriod := func(m map[statKey]map[string]*PeriodStat, k statKey, period string, hours float64) {
+		if m[k] == nil {
+			m[k] = map[string]*PeriodStat{}
+		}
+		p := m[k][period]
+		if p == nil {
+			p = &PeriodStat{UserID: k.UserID, ClubID: k.ClubID, Period: period}
+			m[k][period] = p
+		}
+		p.Sessions++
+		p.Hours += hours
+	}
+
+	for _, r := range rows {
+		if r.SigninAt == nil {
+			continue
+		}
+		k := statKey{ClubID: r.ClubID, UserID: r.UserID}
+		ms := memberMap[k]
+		if ms == nil {
+			ms = &MemberStat{UserID: r.UserID, ClubID: r.ClubID, weeks: map[string]bool{}}
+			memberMap[k] = ms
+		}
+		ms.Sessions++
+		ms.TotalMinutes += r.DurationMinutes
+		ms.TotalHours += r.DurationHours
+		ms.WeightedHours += r.WeightedHours
+		ms.weeks[weekStart(*r.SigninAt).Format("2006-01-02")] = true
+
+		addPeriod(monthMap, k, r.SigninAt.Format("2006-01"), r.DurationHours)
+		y, w := r.SigninAt.ISOWeek()
+		addPeriod(weekMap, k, strconv.Itoa(y)+"-W"+twoDigits(w), r.DurationHours)
+	}
+
+	members := make([]MemberStat, 0, len(memberMap))
+	for _, ms := range memberMap {
+		ms.TotalHours = round2(ms.TotalHours)
+		ms.WeightedHours = round2(ms.WeightedHours)
+		if ms.Sessions > 0 {
+			ms.AvgSessionMinutes = round2(float64(ms.TotalMinutes) / float64(ms.Sessions))
+		}
+		ms.LongestStreakWeeks, ms.CurrentStreakWeeks = weekStreaks(ms.weeks, now)
+		members = append(members, *ms)
+	}
+	// 社团内按总时长排名，时长相同并列
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].ClubID != members[j].ClubID {
+			return members[i].ClubID < members[j].ClubID
+		}
+		if members[i].TotalHours != members[j].TotalHours {
+			return members[i].TotalHours > members[j].TotalHours
+		}
+		return members[i].UserID < members[j].UserID
+	})
+	for i := range members {
+		switch {
+		case i > 0 && members[i].ClubID == members[i-1].ClubID && members[i].TotalHours == members[i-1].TotalHours:
+			members[i].Rank = members[i-1].Rank
+		case i > 0 && members[i].ClubID == members[i-1].ClubID:
+			members[i].Rank = i + 1 - firstIndexOfClub(members, i)
+		default:
+			members[i].Rank = 1
+		}
+	}
+
+	return members, flattenPeriods(monthMap), flattenPeriods(weekMap)
+}
+
+func firstIndexOfClub(members []MemberStat, i int) int {
+	j := i
+	for j > 0 && members[j-1].ClubID == members[i].ClubID {
+		j--
+	}
+	return j
+}
+
+func flattenPeriods(m map[statKey]map[string]*PeriodStat) []PeriodStat {
+	list := make([]PeriodStat, 0)
+	for _, periods := range m {
+		for _, p := range periods {
+			p.Hours = round2(p.Hours)
+			list = append(list, *p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ClubID != list[j].ClubID {
+			return list[i].ClubID < list[j].ClubID
+		}
+		if list[i].UserID != list[j].UserID {
+			return list[i].UserID < list[j].UserID
+		}
+		return list[i].Period < list[j].Period
+	})
+	return list
+}
+
+// weekStreaks 计算最长连续出勤周数与当前连续出勤周数
+// 本周尚未出勤时，截至上周的连续周数仍计为当前连续
+func weekStreaks(weeks map[string]bool, now time.Time) (longest int, current int) {
+	keys := make([]string, 0, len(weeks))
+	for k := range weeks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	run := 0
+	var prev time.Time
+	for i, k := range keys {
+		t, _ := time.ParseInLocation("2006-01-02", k, time.Local)
+		if i > 0 && t.Sub(prev) <= 8*24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+		prev = t
+	}
+	if len(keys) == 0 {
+		return 0, 0
+	}
+	thisWeek := weekStart(now)
+	lastWeek := thisWeek.AddDate(0, 0, -7)
+	last, _ := time.ParseInLocation("2006-01-02", keys[len(keys)-1], time.Local)
+	if last.Equal(thisWeek) || last.Equal(lastWeek) {
+		current = run
+	}
+	return longest, current
+}
+
+// weekStart 返回所在周的周一零点
+func weekStart(t time.Time) time.Time {
+	t = t.In(time.Local)
+	offset := (int(t.Weekday()) + 6) % 7
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return d.AddDate(0, 0, -offset)
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// fillStatNames 补充成员姓名、学号与社团名称
+func fillStatNames(members []MemberStat) {
+	if len(members) == 0 {
+		return
+	}
+	userIDs := make([]uint, 0, len(members))
+	clubIDs := make([]uint, 0)
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
+		clubIDs = append(clubIDs, m.ClubID)
+	}
+	var users []models.User
+	store.DB().Where("id IN ?", userIDs).Find(&users)
+	var clubs []models.Club
+	store.DB().Where("id IN ?", clubIDs).Find(&clubs)
+	userMap := make(map[uint]models.User, len(users))
+	for _, u := range users {
+		userMap[u.ID] = u
+	}
+	clubMap := make(map[uint]string, len(clubs))
+	for _, cl := range clubs {
+		clubMap[cl.ID] = cl.Name
+	}
+	for i := range members {
+		members[i].UserName = userMap[members[i].UserID].Name
+		members[i].StudentNo = userMap[members[i].UserID].StudentNo
+		members[i].ClubName = clubMap[members[i].ClubID]
+	}
+}
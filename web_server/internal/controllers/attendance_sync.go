@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"web_server/config"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 单次补传的最大事件数
+const maxSyncEvents = 100
+
+type RegisterDeviceReq struct {
+	Name string `json:"name"`
+}
+
+type SyncEvent struct {
+	Key        string `json:"key"`         // 客户端生成的幂等键
+	Action     string `json:"action"`      // signin, signout
+	ClubID     uint   `json:"club_id"`     // 社团签到时填写
+	ActivityID uint   `json:"activity_id"` // 活动签到时填写，优先于 club_id
+	Type       string `json:"type"`        // 签到类型，仅 signin 使用
+	ClientTime int64  `json:"client_time"` // 客户端时间，毫秒时间戳
+	Signature  string `json:"signature"`   // 设备签名
+}
+
+type SyncAttendanceReq struct {
+	DeviceID uint        `json:"device_id" binding:"required"`
+	Events   []SyncEvent `json:"events" binding:"required"`
+}
+
+type SyncResult struct {
+	Key          string `json:"key"`
+	Status       string `json:"status"` // ok, duplicate, rejected, error
+	Message      string `json:"message,omitempty"`
+	AttendanceID uint   `json:"attendance_id,omitempty"`
+}
+
+// @Summary 注册签到设备（返回签名密钥，仅返回一次）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param payload body RegisterDeviceReq false "设备信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/devices [post]
+func RegisterDevice(c *gin.Context) {
+	var req RegisterDeviceReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	secret, err := attendance.NewDeviceSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "注册失败"))
+		return
+	}
+	d := models.Device{UserID: u.ID, Name: req.Name, Secret: secret}
+	if err := store.DB().Create(&d).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "注册失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"device_id": d.ID, "secret": secret}))
+}
+
+// @Summary 离线签到补传
+// @Description 按客户端时间顺序重放签到/签退事件，规则与在线签到一致；按幂等键去重，逐条返回结果
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param payload body SyncAttendanceReq true "离线事件"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/attendance/sync [post]
+func SyncAttendance(c *gin.Context) {
+	var req SyncAttendanceReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if len(req.Events) > maxSyncEvents {
+		c.JSON(http.StatusBadRequest, response.Error(400, fmt.Sprintf("单次最多补传 %d 条", maxSyncEvents)))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var dev models.Device
+	if err := store.DB().Where("id = ? AND user_id = ?", req.DeviceID, u.ID).First(&dev).Error; err != nil {
+		c.JSON(http.StatusForbidden, response.Error(403, "设备未注册"))
+		return
+	}
+
+	events := append([]SyncEvent(nil), req.Events...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].ClientTime < events[j].ClientTime })
+
+	cfg := config.Default().Attendance
+	now := time.Now()
+	minTime := now.Add(-time.Duration(cfg.SyncMaxAgeHours) * time.Hour)
+	maxTime := now.Add(time.Duration(cfg.SyncMaxFutureSeconds) * time.Second)
+	results := make([]SyncResult, 0, len(events))
+	for _, ev := range events {
+		results = append(results, replaySyncEvent(u, dev, ev, minTime, maxTime))
+	}
+	store.DB().Model(&dev).Update("last_seen_at", now)
+	c.JSON(http.StatusOK, response.Success(results))
+}
+
+// replaySyncEvent 校验并重放单条离线事件
+func replaySyncEvent(u *models.User, dev models.Device, ev SyncEvent, minTime, maxTime time.Time) SyncResult {
+	res := SyncResult{Key: ev.Key}
+	reject := func(msg string) SyncResult {
+		res.Status, res.Message = "rejected", msg
+		return res
+	}
+	if ev.Key == "" || len(ev.Key) > 64 {
+		return reject("幂等键无效")
+	}
+	if ev.Action != "signin" && ev.Action != "signout" {
+		return reject("不支持的操作")
+	}
+	var target string
+	switch {
+	case ev.ActivityID > 0:
+		target = fmt.Sprintf("activity:%d", ev.ActivityID)
+	case ev.ClubID > 0:
+		target = fmt.Sprintf("club:%d", ev.ClubID)
+	default:
+		return reject("缺少活动或社团")
+	}
+	if !attendance.VerifyEvent(dev.Secret, ev.Key, ev.Action, target, ev.ClientTime, strings.ToLower(ev.Signature)) {
+		return reject("签名校验失败")
+	}
+
+	// 已处理过的幂等键直接返回原结果
+	var prev models.AttendanceSyncEvent
+	if err := store.DB().Where("user_id = ? AND `key` = ?", u.ID, ev.Key).First(&prev).Error; err == nil {
+		res.Status, res.Message, res.AttendanceID = "duplicate", prev.Message, prev.AttendanceID
+		return res
+	}
+
+	at := time.UnixMilli(ev.ClientTime)
+	rec := models.AttendanceSyncEvent{UserID: u.ID, Key: ev.Key, DeviceID: dev.ID, Action: ev.Action, ClubID: ev.ClubID, ClientTime: at}
+	if ev.ActivityID > 0 {
+		aid := ev.ActivityID
+		rec.ActivityID = &aid
+	}
+	// 先占用幂等键，并发补传同一事件时只有一条能写入
+	if err := store.DB().Create(&rec).Error; err != nil {
+		res.Status = "duplicate"
+		return res
+	}
+
+	var att *models.Attendance
+	var e *attendanceError
+	if at.Before(minTime) || at.After(maxTime) {
+		e = attErr(http.StatusBadRequest, "客户端时间超出允许范围")
+	} else {
+		switch {
+		case ev.Action == "signin" && ev.ActivityID > 0:
+			att, e = signInActivity(u, ev.ActivityID, ev.Type, at)
+		case ev.Action == "signin":
+			att, e = signInClub(u, ev.ClubID, ev.Type, at)
+		case ev.ActivityID > 0:
+			att, _, e = signOutActivity(u, ev.ActivityID, at)
+		default:
+			att, _, e = signOutClub(u, ev.ClubID, at)
+		}
+	}
+	if e != nil && e.Status >= http.StatusInternalServerError {
+		// 服务端错误释放幂等键，允许客户端重试
+		store.DB().Delete(&rec)
+		res.Status, res.Message = "error", e.Msg
+		return res
+	}
+	if e != nil {
+		rec.Status, rec.Message = "rejected", e.Msg
+	} else {
+		rec.Status, rec.AttendanceID = "ok", att.ID
+		if att.VoidedAt != nil {
+			rec.Message = att.VoidReason
+		}
+	}
+	store.DB().Model(&rec).Updates(map[string]any{"status": rec.Status, "message": rec.Message, "attendance_id": rec.AttendanceID})
+	res.Status, res.Message, res.AttendanceID = rec.Status, rec.Message, rec.AttendanceID
+	return res
+}
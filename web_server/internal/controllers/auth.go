@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"web_server/config"
 	"web_server/db/models"
+	"web_server/internal/attendance"
 	"web_server/internal/store"
 	"web_server/pkg/jwt"
 	"web_server/pkg/password"
@@ -113,7 +114,7 @@ func MyProfile(c *gin.Context) {
 	var clubCount int64
 	var actCount int64
 	_ = store.DB().Model(&models.Membership{}).Where("user_id = ? AND status = ?", u.ID, "approved").Count(&clubCount).Error
-	_ = store.DB().Model(&models.Attendance{}).Where("user_id = ?", u.ID).Count(&actCount).Error
+	_ = store.DB().Model(&models.Attendance{}).Scopes(attendance.Valid).Where("user_id = ?", u.ID).Count(&actCount).Error
 	res := map[string]any{
 		"id":             u.ID,
 		"account":        u.Account,
@@ -5,14 +5,17 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 	"web_server/db/models"
 	"web_server/internal/authz"
+	"web_server/internal/membership"
 	"web_server/internal/store"
 	"web_server/pkg/pagination"
 	"web_server/pkg/password"
 	"web_server/pkg/response"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type RegisterClubReq struct {
@@ -81,14 +84,13 @@ func RegisterClub(c *gin.Context) {
 		return
 	}
 
-	// Create Membership as Leader
+	// Create Membership as Leader, automatically approved as creator
 	member := models.Membership{
 		UserID: u.ID,
 		ClubID: club.ID,
 		Role:   "leader",
-		Status: "approved", // Automatically approved as creator
 	}
-	if err := tx.Create(&member).Error; err != nil {
+	if err := membership.Transit(tx, &member, "approved", u.ID, time.Now()); err != nil {
 		tx.Rollback()
 		c.JSON(http.StatusInternalServerError, response.Error(500, "注册失败"))
 		return
@@ -146,7 +148,7 @@ func ListClubs(c *gin.Context) {
 	items := make([]ClubItem, 0, len(clubs))
 	for _, cl := range clubs {
 		var leader models.Membership
-		_ = store.DB().Where("club_id = ? AND role IN ?", cl.ID, []string{"leader", "advisor"}).Preload("User").Order("id ASC").First(&leader)
+		_ = store.DB().Where("club_id = ? AND role IN ? AND status = ?", cl.ID, []string{"leader", "advisor"}, "approved").Preload("User").Order("id ASC").First(&leader)
 		var acts []models.Activity
 		_ = store.DB().Where("club_id = ? AND scope = ?", cl.ID, "public").Order("id DESC").Limit(3).Find(&acts)
 		ai := make([]ActivityItem, 0, len(acts))
@@ -168,9 +170,12 @@ func ListClubs(c *gin.Context) {
 }
 
 // @Summary 申请加入社团
+// @Description 社团设置了申请问题时需在请求体中提交回答，可附带附件图片
 // @Tags 学生
+// @Accept json
 // @Produce json
 // @Param clubId path int true "社团ID"
+// @Param payload body ApplyReq false "申请回答与附件"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /student/clubs/{clubId}/apply [post]
@@ -181,24 +186,81 @@ func ApplyJoinClub(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
 		return
 	}
+	var req ApplyReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
+	answers, msg := buildAnswers(cl.ID, req.Answers)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	var m models.Membership
-	if err := store.DB().Where("user_id = ? AND club_id = ?", u.ID, cl.ID).First(&m).Error; err == nil {
-		m.Status = "pending"
-		m.Role = "member"
-		if err := store.DB().Save(&m).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+	var campaignID *uint
+	if camp := activeCampaign(store.DB(), cl.ID, time.Now()); camp != nil {
+		if msg := campaignQuotaError(store.DB(), camp, u.College, 0); msg != "" {
+			c.JSON(http.StatusBadRequest, response.Error(400, msg))
 			return
 		}
-		c.JSON(http.StatusOK, response.Success(m))
+		campaignID = &camp.ID
+	} else if cl.RequireCampaign {
+		c.JSON(http.StatusBadRequest, response.Error(400, "当前不在招新期"))
 		return
 	}
-	m = models.Membership{UserID: u.ID, ClubID: cl.ID, Status: "pending", Role: "member"}
-	if err := store.DB().Create(&m).Error; err != nil {
+	tx := store.DB().Begin()
+	var m models.Membership
+	found := tx.Where("user_id = ? AND club_id = ?", u.ID, cl.ID).First(&m).Error == nil
+	m.UserID, m.ClubID = u.ID, cl.ID
+	m.Role = "member"
+	m.Attachment = req.Attachment
+	m.CampaignID = campaignID
+	if err := membership.Transit(tx, &m, "pending", u.ID, time.Now()); err != nil {
+		tx.Rollback()
+		if membership.IsStateError(err) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
 		return
 	}
+	if found {
+		// 重新申请时以本次回答为准，上次的面试预约一并清除
+		if err := tx.Where("membership_id = ?", m.ID).Delete(&models.ApplicationAnswer{}).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+			return
+		}
+		var ivs []models.Interview
+		if err := tx.Where("membership_id = ?", m.ID).Find(&ivs).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+			return
+		}
+		for _, iv := range ivs {
+			// 释放原预约占用的名额
+			if err := tx.Model(&models.InterviewSlot{}).Where("id = ? AND booked > 0", iv.SlotID).Update("booked", gorm.Expr("booked - 1")).Error; err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+				return
+			}
+			if err := tx.Delete(&iv).Error; err != nil {
+				tx.Rollback()
+				c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+				return
+			}
+		}
+	}
+	for i := range answers {
+		answers[i].MembershipID = m.ID
+	}
+	if len(answers) > 0 {
+		if err := tx.Create(&answers).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+			return
+		}
+	}
+	tx.Commit()
+	m.Answers = answers
 	c.JSON(http.StatusOK, response.Success(m))
 }
 
@@ -206,6 +268,7 @@ func ApplyJoinClub(c *gin.Context) {
 // @Tags 学生
 // @Produce json
 // @Param status query string false "状态: pending/approved/rejected/quit"
+// @Param termId query string false "学期ID，默认当前学期，all 表示不限学期"
 // @Param page query int false "页码"
 // @Param pageSize query int false "每页数量"
 // @Security Bearer
@@ -218,9 +281,20 @@ func MyMemberships(c *gin.Context) {
 	if st := c.Query("status"); st != "" {
 		q = q.Where("status = ?", st)
 	}
+	term, ok := termFilter(c, "termId")
+	if !ok {
+		return
+	}
 	var list []models.Membership
 	pg := pagination.Get(c)
-	info, err := pagination.Do(q, pg, &list)
+	var info pagination.Info
+	var err error
+	if term == nil {
+		info, err = pagination.Do(q, pg, &list)
+	} else {
+		// 按加入、离开时间判断学期内是否在社，每人每社只有一条记录，直接在内存中筛选分页
+		list, info, err = membershipsInTerm(q, term, pg)
+	}
 	if err != nil {
 		log.Printf("MyMemberships query error: user_id=%d status=%s err=%v", u.ID, c.Query("status"), err)
 		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
@@ -229,10 +303,38 @@ func MyMemberships(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
 }
 
+// membershipsInTerm 返回学期内在社或有申请记录的成员关系
+func membershipsInTerm(q *gorm.DB, term *models.Term, pg pagination.Query) ([]models.Membership, pagination.Info, error) {
+	var all []models.Membership
+	if err := q.Find(&all).Error; err != nil {
+		return nil, pagination.Info{}, err
+	}
+	from := time.Date(term.StartDate.Year(), term.StartDate.Month(), term.StartDate.Day(), 0, 0, 0, 0, time.Local)
+	to := time.Date(term.EndDate.Year(), term.EndDate.Month(), term.EndDate.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	matched := make([]models.Membership, 0, len(all))
+	for _, m := range all {
+		if membership.ActiveDuring(m, from, to) {
+			matched = append(matched, m)
+		}
+	}
+	info := pagination.Info{Page: pg.Page, PageSize: pg.PageSize, Total: int64(len(matched))}
+	lo := (pg.Page - 1) * pg.PageSize
+	if lo > len(matched) {
+		lo = len(matched)
+	}
+	hi := lo + pg.PageSize
+	if hi > len(matched) {
+		hi = len(matched)
+	}
+	return matched[lo:hi], info, nil
+}
+
 // @Summary 退出社团
 // @Tags 学生
+// @Accept json
 // @Produce json
 // @Param clubId path int true "社团ID"
+// @Param payload body MembershipReasonReq false "退出原因"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /student/clubs/{clubId}/exit [post]
@@ -250,8 +352,19 @@ func ExitClub(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "未加入该社团"))
 		return
 	}
-	m.Status = "quit"
-	if err := store.DB().Save(&m).Error; err != nil {
+	if m.Role == "leader" && m.Status == "approved" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "社长需先完成交接才能退出"))
+		return
+	}
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+	if err := membership.TransitWithReason(store.DB(), &m, "quit", u.ID, time.Now(), reason); err != nil {
+		if membership.IsStateError(err) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.Error(500, "退出失败"))
 		return
 	}
@@ -277,12 +390,12 @@ func ListPendingMemberships(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
 	var list []models.Membership
-	q := store.DB().Model(&models.Membership{}).Where("club_id = ? AND status = ?", clubID, "pending").Preload("User").Order("id DESC")
+	q := store.DB().Model(&models.Membership{}).Where("club_id = ? AND status = ?", clubID, "pending").Preload("User").Preload("Answers").Preload("Interview.Slot").Order("id DESC")
 	if kw := c.Query("keyword"); kw != "" {
 		like := "%%" + kw + "%%"
 		q = q.Where("user_id IN (SELECT id FROM users WHERE name LIKE ? OR student_no LIKE ?)", like, like)
@@ -302,6 +415,7 @@ func ListPendingMemberships(c *gin.Context) {
 // @Produce json
 // @Param clubId path int true "社团ID"
 // @Param id path int true "成员关系ID"
+// @Param payload body MembershipReasonReq false "审批说明"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /leader/clubs/{clubId}/memberships/{id}/approve [post]
@@ -310,7 +424,7 @@ func ApproveMembership(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -319,12 +433,25 @@ func ApproveMembership(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
 		return
 	}
-	m.Status = "approved"
-	if err := store.DB().Save(&m).Error; err != nil {
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+	msg, err := approveApplication(store.DB(), &m, reason, u.ID, time.Now())
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
 		return
 	}
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
 	RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("批准成员 %d 加入社团", m.UserID), uint(clubID))
+	// 返回面试结果供审批记录参考
+	var iv models.Interview
+	if err := store.DB().Preload("Slot").Where("membership_id = ?", m.ID).First(&iv).Error; err == nil {
+		m.Interview = &iv
+	}
 	c.JSON(http.StatusOK, response.Success(m))
 }
 
@@ -334,6 +461,7 @@ func ApproveMembership(c *gin.Context) {
 // @Produce json
 // @Param clubId path int true "社团ID"
 // @Param id path int true "成员关系ID"
+// @Param payload body RejectMembershipReq false "驳回原因"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /leader/clubs/{clubId}/memberships/{id}/reject [post]
@@ -342,7 +470,7 @@ func RejectMembership(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -351,12 +479,21 @@ func RejectMembership(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
 		return
 	}
-	m.Status = "rejected"
-	if err := store.DB().Save(&m).Error; err != nil {
+	var req RejectMembershipReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
+	if len([]rune(req.Reason)) > 255 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "驳回原因过长"))
+		return
+	}
+	if err := rejectApplication(store.DB(), &m, req.Reason, u.ID, time.Now()); err != nil {
+		if membership.IsStateError(err) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
 		return
 	}
-	RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("驳回成员 %d 加入社团", m.UserID), uint(clubID))
+	RecordLog(u.ID, u.Name, "审批申请", rejectLogContent(m.UserID, req.Reason), uint(clubID))
 	c.JSON(http.StatusOK, response.Success(m))
 }
 
@@ -365,7 +502,9 @@ func RejectMembership(c *gin.Context) {
 // @Produce json
 // @Param clubId path int true "社团ID"
 // @Param role query string false "角色: member/leader/advisor"
-// @Param status query string false "状态: pending/approved/rejected/quit"
+// @Param status query string false "状态: pending/approved/rejected/quit/kicked/expired"
+// @Param departmentId query int false "部门ID"
+// @Param tagId query int false "标签ID"
 // @Param keyword query string false "关键词：姓名/学号/手机号"
 // @Param page query int false "页码"
 // @Param pageSize query int false "每页数量"
@@ -381,17 +520,27 @@ func ListClubMembers(c *gin.Context) {
 	}
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
-	q := store.DB().Model(&models.Membership{}).Where("club_id = ?", clubID).Preload("User").Order("id DESC")
+	q := store.DB().Model(&models.Membership{}).Where("club_id = ?", clubID).Preload("User").Preload("Answers").Preload("Tags.Tag").Order("id DESC")
 	if role := c.Query("role"); role != "" {
 		q = q.Where("role = ?", role)
 	}
 	if status := c.Query("status"); status != "" {
 		q = q.Where("status = ?", status)
 	}
+	if tid := c.Query("tagId"); tid != "" {
+		if v, e := strconv.Atoi(tid); e == nil && v > 0 {
+			q = q.Where("id IN (?)", store.DB().Model(&models.MembershipTag{}).Select("membership_id").Where("tag_id = ?", v))
+		}
+	}
+	if did := c.Query("departmentId"); did != "" {
+		if v, e := strconv.Atoi(did); e == nil && v > 0 {
+			q = q.Where("user_id IN (?)", departmentUsers([]uint{uint(v)}))
+		}
+	}
 	if kw := c.Query("keyword"); kw != "" {
 		like := "%%" + kw + "%%"
 		q = q.Where("user_id IN (SELECT id FROM users WHERE name LIKE ? OR student_no LIKE ? OR phone LIKE ?)", like, like, like)
@@ -0,0 +1,435 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errClubChangeClosed  = errors.New("变更申请已处理")
+	errClubChangePending = errors.New("已有待审核的变更申请，请等待审核或撤回后再提交")
+	errClubNameTaken     = errors.New("社团名称已存在")
+)
+
+// UpdateClubProfileReq 仅修改传入的字段；名称与分类需管理员审核，其余立即生效
+type UpdateClubProfileReq struct {
+	Name       *string `json:"name"`
+	CategoryID *uint   `json:"category_id"`
+	Logo       *string `json:"logo"`
+	Intro      *string `json:"intro"`
+	Contact    *string `json:"contact"`
+	Note       string  `json:"note"` // 变更申请说明
+}
+
+type ReviewClubChangeReq struct {
+	Note string `json:"note"` // 驳回原因
+}
+
+// clubNameTaken 名称是否已被其他社团使用或被其他待审核申请占用
+func clubNameTaken(db *gorm.DB, name string, clubID uint) (bool, error) {
+	var n int64
+	if err := db.Model(&models.Club{}).Where("name = ? AND id <> ?", name, clubID).Count(&n).Error; err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return true, nil
+	}
+	err := db.Model(&models.ClubChangeRequest{}).Where("new_name = ? AND club_id <> ? AND status = ?", name, clubID, "pending").Count(&n).Error
+	return n > 0, err
+}
+
+func clubChangeSummary(cr *models.ClubChangeRequest, categories map[uint]string) string {
+	var parts []string
+	if cr.NewName != "" {
+		parts = append(parts, fmt.Sprintf("名称「%s」→「%s」", cr.OldName, cr.NewName))
+	}
+	if cr.NewCategoryID != 0 {
+		parts = append(parts, fmt.Sprintf("分类「%s」→「%s」", categories[cr.OldCategoryID], categories[cr.NewCategoryID]))
+	}
+	return strings.Join(parts, "，")
+}
+
+func categoryNames(ids ...uint) map[uint]string {
+	names := map[uint]string{}
+	var list []models.ClubCategory
+	_ = store.DB().Where("id IN ?", ids).Find(&list).Error
+	for _, c := range list {
+		names[c.ID] = c.Name
+	}
+	return names
+}
+
+// @Summary 修改社团资料（负责人）
+// @Description Logo、简介、联系方式立即生效；名称与分类的修改生成变更申请，管理员审核通过后生效。
+// @Description 管理员调用时全部字段直接生效。同一社团同时只能有一条待审核的变更申请。
+// @Tags 负责人
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body UpdateClubProfileReq true "社团资料"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/profile [put]
+func UpdateClubProfile(c *gin.Context) {
+	clubID, err := strconv.Atoi(c.Param("clubId"))
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	isAdmin := authz.IsAdmin(u)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageClub) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req UpdateClubProfileReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if len([]rune(req.Note)) > 255 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "申请说明过长"))
+		return
+	}
+	var club models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&club).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
+		return
+	}
+
+	updates := map[string]any{}
+	var changed []string
+	if req.Logo != nil && *req.Logo != club.Logo {
+		if len(*req.Logo) > 255 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "Logo 地址过长"))
+			return
+		}
+		updates["logo"] = *req.Logo
+		changed = append(changed, "Logo")
+	}
+	if req.Intro != nil && *req.Intro != club.Intro {
+		if len([]rune(*req.Intro)) > 5000 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "简介不能超过 5000 字"))
+			return
+		}
+		updates["intro"] = *req.Intro
+		changed = append(changed, "简介")
+	}
+	if req.Contact != nil && *req.Contact != club.Contact {
+		if len([]rune(*req.Contact)) > 64 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "联系方式过长"))
+			return
+		}
+		updates["contact"] = *req.Contact
+		changed = append(changed, "联系方式")
+	}
+
+	cr := models.ClubChangeRequest{ClubID: club.ID, RequestedBy: u.ID, OldName: club.Name, OldCategoryID: club.CategoryID, Note: req.Note, Status: "pending"}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len([]rune(name)) > 64 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "社团名称不能为空且不超过 64 字"))
+			return
+		}
+		if name != club.Name {
+			cr.NewName = name
+		}
+	}
+	if req.CategoryID != nil && *req.CategoryID != club.CategoryID {
+		var cat models.ClubCategory
+		if err := store.DB().Where("id = ?", *req.CategoryID).First(&cat).Error; err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "分类不存在"))
+			return
+		}
+		cr.NewCategoryID = cat.ID
+	}
+	sensitive := cr.NewName != "" || cr.NewCategoryID != 0
+	if len(updates) == 0 && !sensitive {
+		c.JSON(http.StatusBadRequest, response.Error(400, "没有需要修改的内容"))
+		return
+	}
+
+	var created *models.ClubChangeRequest
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		if cr.NewName != "" {
+			taken, err := clubNameTaken(tx, cr.NewName, club.ID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return errClubNameTaken
+			}
+		}
+		if sensitive && isAdmin {
+			// 管理员直接修改，无需再走审核
+			if cr.NewName != "" {
+				updates["name"] = cr.NewName
+			}
+			if cr.NewCategoryID != 0 {
+				updates["category_id"] = cr.NewCategoryID
+			}
+		} else if sensitive {
+			var n int64
+			if err := tx.Model(&models.ClubChangeRequest{}).Where("club_id = ? AND status = ?", club.ID, "pending").Count(&n).Error; err != nil {
+				return err
+			}
+			if n > 0 {
+				return errClubChangePending
+			}
+			if err := tx.Create(&cr).Error; err != nil {
+				return err
+			}
+			created = &cr
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&club).Updates(updates).Error
+	})
+	if err != nil {
+		if errors.Is(err, errClubNameTaken) || errors.Is(err, errClubChangePending) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+
+	categories := categoryNames(cr.OldCategoryID, cr.NewCategoryID)
+	if sensitive && isAdmin {
+		changed = append(changed, clubChangeSummary(&cr, categories))
+	}
+	if len(changed) > 0 {
+		RecordLog(u.ID, u.Name, "修改社团资料", "修改了"+strings.Join(changed, "、"), club.ID)
+	}
+	if created != nil {
+		RecordLog(u.ID, u.Name, "社团变更申请", "提交变更申请："+clubChangeSummary(created, categories), club.ID)
+	}
+	_ = store.DB().Preload("Category").Where("id = ?", club.ID).First(&club).Error
+	c.JSON(http.StatusOK, response.Success(map[string]any{"club": club, "change_request": created}))
+}
+
+// @Summary 社团变更申请记录（负责人）
+// @Tags 负责人
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/change-requests [get]
+func ListClubChangeRequests(c *gin.Context) {
+	clubID, err := strconv.Atoi(c.Param("clubId"))
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageClub) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	q := store.DB().Model(&models.ClubChangeRequest{}).Where("club_id = ?", clubID).Preload("Requester").Order("id DESC")
+	var list []models.ClubChangeRequest
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q, pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 撤回社团变更申请（负责人）
+// @Tags 负责人
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "申请ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/change-requests/{id}/cancel [post]
+func CancelClubChangeRequest(c *gin.Context) {
+	clubID, err1 := strconv.Atoi(c.Param("clubId"))
+	id, err2 := strconv.Atoi(c.Param("id"))
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageClub) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	res := store.DB().Model(&models.ClubChangeRequest{}).Where("id = ? AND club_id = ? AND status = ?", id, clubID, "pending").Update("status", "cancelled")
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "申请不存在或已处理"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "社团变更申请", fmt.Sprintf("撤回变更申请 %d", id), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 社团变更申请列表（管理员）
+// @Tags 管理员
+// @Produce json
+// @Param status query string false "状态(pending/approved/rejected/cancelled)"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/club-changes [get]
+func ListAllClubChangeRequests(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	q := store.DB().Model(&models.ClubChangeRequest{}).Preload("Club").Preload("Requester")
+	if s := c.Query("status"); s != "" {
+		q = q.Where("status = ?", s)
+	}
+	var list []models.ClubChangeRequest
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 通过社团变更申请（管理员）
+// @Tags 管理员
+// @Produce json
+// @Param id path int true "申请ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/club-changes/{id}/approve [post]
+func ApproveClubChangeRequest(c *gin.Context) {
+	reviewClubChangeRequest(c, true)
+}
+
+// @Summary 驳回社团变更申请（管理员）
+// @Tags 管理员
+// @Accept json
+// @Produce json
+// @Param id path int true "申请ID"
+// @Param payload body ReviewClubChangeReq false "驳回原因"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/club-changes/{id}/reject [post]
+func RejectClubChangeRequest(c *gin.Context) {
+	reviewClubChangeRequest(c, false)
+}
+
+func reviewClubChangeRequest(c *gin.Context, approve bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req ReviewClubChangeReq
+	_ = c.ShouldBindJSON(&req)
+	if len([]rune(req.Note)) > 255 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "驳回原因过长"))
+		return
+	}
+	var cr models.ClubChangeRequest
+	if err := store.DB().Where("id = ?", id).First(&cr).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "申请不存在"))
+		return
+	}
+	now := time.Now()
+	status := "rejected"
+	if approve {
+		status = "approved"
+	}
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&cr).Where("status = ?", "pending").Updates(map[string]any{"status": status, "reviewed_by": u.ID, "reviewed_at": now, "review_note": req.Note})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errClubChangeClosed
+		}
+		if !approve {
+			return nil
+		}
+		updates := map[string]any{}
+		if cr.NewName != "" {
+			taken, err := clubNameTaken(tx, cr.NewName, cr.ClubID)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return errClubNameTaken
+			}
+			updates["name"] = cr.NewName
+		}
+		if cr.NewCategoryID != 0 {
+			updates["category_id"] = cr.NewCategoryID
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&models.Club{}).Where("id = ?", cr.ClubID).Updates(updates).Error
+	})
+	if err != nil {
+		if errors.Is(err, errClubChangeClosed) || errors.Is(err, errClubNameTaken) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	cr.Status, cr.ReviewedBy, cr.ReviewedAt, cr.ReviewNote = status, u.ID, &now, req.Note
+
+	summary := clubChangeSummary(&cr, categoryNames(cr.OldCategoryID, cr.NewCategoryID))
+	title, content := "社团变更申请已通过", "您提交的社团变更已生效："+summary
+	logContent := "通过变更申请：" + summary
+	if !approve {
+		title, content = "社团变更申请被驳回", "您提交的社团变更未通过："+summary
+		logContent = "驳回变更申请：" + summary
+		if req.Note != "" {
+			content += "。原因：" + req.Note
+			logContent += "，原因：" + req.Note
+		}
+	}
+	store.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Notification{
+		UserID:  cr.RequestedBy,
+		ClubID:  cr.ClubID,
+		Kind:    "club_change",
+		Title:   title,
+		Content: content,
+		RefKey:  fmt.Sprintf("club_change:%d", cr.ID),
+	})
+	RecordLog(u.ID, u.Name, "社团变更申请", logContent, cr.ClubID)
+	c.JSON(http.StatusOK, response.Success(cr))
+}
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClubRoleReq struct {
+	Name        string   `json:"name" binding:"required"`
+	Permissions []string `json:"permissions"` // 取值见角色列表返回的 permissions
+}
+
+// validRolePermissions 校验权限取值，并确认操作人不能创建超出自身权限的角色
+func validRolePermissions(u *models.User, clubID uint, perms []string) string {
+	for _, p := range perms {
+		if !authz.CoversPermissions(authz.AllPermissions, []string{p}) {
+			return "未知权限：" + p
+		}
+	}
+	if authz.IsAdmin(u) {
+		return ""
+	}
+	var m models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ?", u.ID, clubID).First(&m).Error; err != nil || !authz.CoversPermissions(authz.RolePermissions(clubID, m.Role), perms) {
+		return "不能创建超出自身权限的角色"
+	}
+	return ""
+}
+
+// @Summary 社团角色列表（含内置角色与可用权限）
+// @Tags 负责人
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/roles [get]
+func ListClubRoles(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRoles) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var custom []models.ClubRole
+	if err := store.DB().Where("club_id = ?", clubID).Order("id ASC").Find(&custom).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	roles := append(append([]models.ClubRole{}, authz.BuiltInRoles...), custom...)
+	c.JSON(http.StatusOK, response.Success(map[string]any{"roles": roles, "permissions": authz.AllPermissions}))
+}
+
+// @Summary 创建自定义角色（负责人）
+// @Tags 负责人
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body ClubRoleReq true "角色名称与权限"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/roles [post]
+func CreateClubRole(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRoles) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req ClubRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if msg := validRolePermissions(u, uint(clubID), req.Permissions); msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	if req.Permissions == nil {
+		req.Permissions = []string{}
+	}
+	// 编码随机生成，避免与内置角色冲突，也不随改名变化
+	suffix, err := newInviteCode()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	r := models.ClubRole{ClubID: uint(clubID), Code: "custom_" + strings.ToLower(suffix), Name: strings.TrimSpace(req.Name), Permissions: req.Permissions}
+	if err := store.DB().Create(&r).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改权限", fmt.Sprintf("创建角色「%s」，权限：%s", r.Name, strings.Join(r.Permissions, ",")), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(r))
+}
+
+// @Summary 更新自定义角色（负责人）
+// @Tags 负责人
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "角色ID"
+// @Param payload body ClubRoleReq true "角色名称与权限"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/roles/{id} [put]
+func UpdateClubRole(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRoles) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req ClubRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var r models.ClubRole
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&r).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "角色不存在"))
+		return
+	}
+	if msg := validRolePermissions(u, uint(clubID), req.Permissions); msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	// 只能修改权限低于自己的角色：既不能给自己所用角色提权，也不能削减上级角色的权限
+	if !authz.IsAdmin(u) {
+		var m models.Membership
+		if err := store.DB().Where("user_id = ? AND club_id = ? AND status = ?", u.ID, clubID, "approved").First(&m).Error; err != nil {
+			c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+			return
+		}
+		if !authz.Outranks(uint(clubID), m.Role, r.Code) {
+			c.JSON(http.StatusForbidden, response.Error(403, "权限不足：只能修改权限低于自己的角色"))
+			return
+		}
+	}
+	if req.Permissions == nil {
+		req.Permissions = []string{}
+	}
+	r.Name = strings.TrimSpace(req.Name)
+	r.Permissions = req.Permissions
+	if err := store.DB().Save(&r).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改权限", fmt.Sprintf("修改角色「%s」，权限：%s", r.Name, strings.Join(r.Permissions, ",")), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(r))
+}
+
+// @Summary 删除自定义角色（负责人）
+// @Description 仍有成员使用该角色时不能删除
+// @Tags 负责人
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "角色ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/roles/{id} [delete]
+func DeleteClubRole(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRoles) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var r models.ClubRole
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&r).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "角色不存在"))
+		return
+	}
+	var used int64
+	store.DB().Model(&models.Membership{}).Where("club_id = ? AND role = ?", clubID, r.Code).Count(&used)
+	if used > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, fmt.Sprintf("仍有 %d 名成员使用该角色", used)))
+		return
+	}
+	if err := store.DB().Delete(&r).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改权限", fmt.Sprintf("删除角色「%s」", r.Name), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
@@ -0,0 +1,438 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errDepartmentInUse 部门仍有仅本部门可见的公告
+var errDepartmentInUse = errors.New("department has announcements")
+
+type DepartmentReq struct {
+	Name  string `json:"name" binding:"required"`
+	Intro string `json:"intro"`
+	Sort  int    `json:"sort"`
+}
+
+type DepartmentMemberReq struct {
+	UserID uint `json:"user_id" binding:"required"`
+	IsHead bool `json:"is_head"`
+}
+
+type DepartmentItem struct {
+	models.Department
+	MemberCount int64         `json:"member_count"`
+	Heads       []models.User `json:"heads"`
+}
+
+// departmentUsers 部门成员的用户ID子查询
+func departmentUsers(deptIDs []uint) *gorm.DB {
+	return store.DB().Model(&models.DepartmentMember{}).Select("user_id").Where("department_id IN ?", deptIDs)
+}
+
+// departmentScope 解析部门筛选参数并校验权限，校验失败时已写入响应。
+// 拥有社团权限 perm 的成员不指定部门时返回 nil（不过滤）；部门负责人只能访问自己负责的部门，未指定时限定为全部所负责部门。
+func departmentScope(c *gin.Context, u *models.User, clubID uint, param string, perm string) ([]uint, bool) {
+	full := authz.HasClubPermission(u, clubID, perm)
+	var heads []uint
+	if !full {
+		heads = authz.HeadedDepartments(u.ID, clubID)
+		if len(heads) == 0 {
+			c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+			return nil, false
+		}
+	}
+	s := c.Query(param)
+	if s == "" {
+		return heads, true
+	}
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return nil, false
+	}
+	var dept models.Department
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&dept).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "部门不存在"))
+		return nil, false
+	}
+	if !full && !containsUint(heads, dept.ID) {
+		c.JSON(http.StatusForbidden, response.Error(403, "只能管理自己负责的部门"))
+		return nil, false
+	}
+	return []uint{dept.ID}, true
+}
+
+// departmentReportScope 考勤报表的社团范围；指定 department_id 时收窄到该部门，部门负责人可凭此查看本部门报表
+func departmentReportScope(c *gin.Context, u *models.User) (clubIDs []uint, all bool, dept *models.Department, ok bool) {
+	clubIDStr := c.Query("club_id")
+	if s := c.Query("department_id"); s != "" {
+		id, err := strconv.Atoi(s)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+			return nil, false, nil, false
+		}
+		var d models.Department
+		if err := store.DB().Where("id = ?", id).First(&d).Error; err != nil {
+			c.JSON(http.StatusNotFound, response.Error(404, "部门不存在"))
+			return nil, false, nil, false
+		}
+		if clubIDStr != "" && clubIDStr != strconv.Itoa(int(d.ClubID)) {
+			c.JSON(http.StatusBadRequest, response.Error(400, "部门不属于该社团"))
+			return nil, false, nil, false
+		}
+		if containsUint(authz.HeadedDepartments(u.ID, d.ClubID), d.ID) {
+			return []uint{d.ClubID}, false, &d, true
+		}
+		dept = &d
+		clubIDStr = strconv.Itoa(int(d.ClubID))
+	}
+	clubIDs, all, allowed, err := managedClubScope(u, clubIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return nil, false, nil, false
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限查看该社团考勤"))
+		return nil, false, nil, false
+	}
+	return clubIDs, all, dept, true
+}
+
+// canManageDepartmentContent 有公告权限的成员可管理全社团公告，部门负责人仅限本部门（deptID 为空表示全社团）
+func canManageDepartmentContent(u *models.User, clubID uint, deptID *uint) bool {
+	if authz.HasClubPermission(u, clubID, authz.PermPublishAnnouncements) {
+		return true
+	}
+	return deptID != nil && containsUint(authz.HeadedDepartments(u.ID, clubID), *deptID)
+}
+
+// canManageMemberAttendance 有考勤权限的成员可管理社团全部考勤，部门负责人仅限本部门成员
+func canManageMemberAttendance(u *models.User, att *models.Attendance) bool {
+	return authz.HasClubPermission(u, att.ClubID, authz.PermManageAttendance) || authz.IsDepartmentHeadOf(u.ID, att.ClubID, att.UserID)
+}
+
+func containsUint(list []uint, v uint) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// @Summary 部门列表（负责人/部门负责人）
+// @Tags 部门
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments [get]
+func ListDepartments(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !(authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) || len(authz.HeadedDepartments(u.ID, uint(clubID))) > 0) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var depts []models.Department
+	if err := store.DB().Where("club_id = ?", clubID).Order("sort ASC, id ASC").Find(&depts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	items := make([]DepartmentItem, 0, len(depts))
+	for _, d := range depts {
+		item := DepartmentItem{Department: d, Heads: []models.User{}}
+		store.DB().Model(&models.DepartmentMember{}).Where("department_id = ?", d.ID).Count(&item.MemberCount)
+		store.DB().Where("id IN (?)", store.DB().Model(&models.DepartmentMember{}).Select("user_id").Where("department_id = ? AND is_head = ?", d.ID, true)).Find(&item.Heads)
+		items = append(items, item)
+	}
+	c.JSON(http.StatusOK, response.Success(items))
+}
+
+// @Summary 创建部门（负责人）
+// @Tags 部门
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body DepartmentReq true "部门信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments [post]
+func CreateDepartment(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req DepartmentReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var cnt int64
+	store.DB().Model(&models.Department{}).Where("club_id = ? AND name = ?", clubID, strings.TrimSpace(req.Name)).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "部门名称已存在"))
+		return
+	}
+	d := models.Department{ClubID: uint(clubID), Name: strings.TrimSpace(req.Name), Intro: req.Intro, Sort: req.Sort}
+	if err := store.DB().Create(&d).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "部门管理", fmt.Sprintf("创建部门: %s", d.Name), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(d))
+}
+
+// @Summary 更新部门（负责人）
+// @Tags 部门
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "部门ID"
+// @Param payload body DepartmentReq true "部门信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments/{id} [put]
+func UpdateDepartment(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req DepartmentReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var d models.Department
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&d).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "部门不存在"))
+		return
+	}
+	var cnt int64
+	store.DB().Model(&models.Department{}).Where("club_id = ? AND name = ? AND id <> ?", clubID, strings.TrimSpace(req.Name), d.ID).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "部门名称已存在"))
+		return
+	}
+	if err := store.DB().Model(&d).Updates(map[string]any{"name": strings.TrimSpace(req.Name), "intro": req.Intro, "sort": req.Sort}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "部门管理", fmt.Sprintf("修改部门 %d: %s", d.ID, d.Name), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(d))
+}
+
+// @Summary 删除部门（负责人）
+// @Description 同时移除部门成员；部门仍有公告时不能删除
+// @Tags 部门
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "部门ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments/{id} [delete]
+func DeleteDepartment(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var d models.Department
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&d).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "部门不存在"))
+		return
+	}
+	var announcements int64
+	err := store.DB().Transaction(func(tx *gorm.DB) error {
+		// 部门公告仅对本部门可见，不能随部门删除而变成全社团可见
+		if err := tx.Model(&models.Announcement{}).Where("department_id = ?", d.ID).Count(&announcements).Error; err != nil {
+			return err
+		}
+		if announcements > 0 {
+			return errDepartmentInUse
+		}
+		if err := tx.Where("department_id = ?", d.ID).Delete(&models.DepartmentMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&d).Error
+	})
+	if errors.Is(err, errDepartmentInUse) {
+		c.JSON(http.StatusBadRequest, response.Error(400, fmt.Sprintf("部门仍有 %d 条公告，请先删除这些公告", announcements)))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "部门管理", fmt.Sprintf("删除部门: %s", d.Name), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 部门成员列表（负责人/部门负责人）
+// @Tags 部门
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "部门ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments/{id}/members [get]
+func ListDepartmentMembers(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !(authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) || containsUint(authz.HeadedDepartments(u.ID, uint(clubID)), uint(id))) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var list []models.DepartmentMember
+	if err := store.DB().Preload("User").Where("department_id = ? AND club_id = ?", id, clubID).Order("is_head DESC, id ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 添加部门成员或设置部门负责人（负责人）
+// @Description 成员已在部门中时更新其是否为负责人
+// @Tags 部门
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "部门ID"
+// @Param payload body DepartmentMemberReq true "成员与负责人标记"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments/{id}/members [post]
+func SetDepartmentMember(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req DepartmentMemberReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var d models.Department
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&d).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "部门不存在"))
+		return
+	}
+	if !authz.IsClubMember(req.UserID, uint(clubID)) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "只能添加社团正式成员"))
+		return
+	}
+	dm := models.DepartmentMember{DepartmentID: d.ID, UserID: req.UserID, ClubID: uint(clubID), IsHead: req.IsHead}
+	if err := store.DB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "department_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"is_head", "updated_at"}),
+	}).Create(&dm).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	action := "加入"
+	if req.IsHead {
+		action = "担任负责人"
+	}
+	RecordLog(u.ID, u.Name, "部门管理", fmt.Sprintf("成员 %d %s部门「%s」", req.UserID, action, d.Name), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(dm))
+}
+
+// @Summary 移出部门成员（负责人）
+// @Tags 部门
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "部门ID"
+// @Param userId path int true "用户ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/departments/{id}/members/{userId} [delete]
+func RemoveDepartmentMember(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(c.Param("id"))
+	userID, err3 := strconv.Atoi(c.Param("userId"))
+	if err1 != nil || err2 != nil || err3 != nil || clubID <= 0 || id <= 0 || userID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	res := store.DB().Where("department_id = ? AND club_id = ? AND user_id = ?", id, clubID, userID).Delete(&models.DepartmentMember{})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, response.Error(404, "该成员不在部门中"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "部门管理", fmt.Sprintf("将成员 %d 移出部门 %d", userID, id), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
@@ -0,0 +1,413 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuesScheduleReq struct {
+	Amount            float64 `json:"amount" binding:"required"`
+	PeriodMonths      int     `json:"period_months" binding:"required"`
+	StartDate         string  `json:"start_date" binding:"required"` // YYYY-MM-DD
+	BlockRegistration bool    `json:"block_registration"`
+}
+
+type DuesPaymentReq struct {
+	MembershipID uint    `json:"membership_id" binding:"required"`
+	Amount       float64 `json:"amount" binding:"required"`
+	Method       string  `json:"method" binding:"required"` // cash, wechat, alipay, transfer, other
+	ReceiptNo    string  `json:"receipt_no"`                // 为空时自动生成
+	PaidAt       string  `json:"paid_at"`                   // YYYY-MM-DD HH:MM:SS，默认当前时间
+	PeriodStart  string  `json:"period_start"`              // 所缴周期的开始日期 YYYY-MM-DD，默认缴费时间所在周期，用于补缴往期
+	Note         string  `json:"note"`
+}
+
+type OutstandingDues struct {
+	MembershipID uint    `json:"membership_id"`
+	UserID       uint    `json:"user_id"`
+	Name         string  `json:"name"`
+	StudentNo    string  `json:"student_no"`
+	Due          float64 `json:"due"`
+	Paid         float64 `json:"paid"`
+	Outstanding  float64 `json:"outstanding"`
+}
+
+// duesPeriod 返回 at 所在的缴费周期，早于开始日期时返回第一个周期
+func duesPeriod(s models.DuesSchedule, at time.Time) (time.Time, time.Time) {
+	start := s.StartDate
+	if s.PeriodMonths <= 0 || at.Before(start) {
+		return start, start.AddDate(0, s.PeriodMonths, 0)
+	}
+	months := (at.Year()-start.Year())*12 + int(at.Month()-start.Month())
+	k := months / s.PeriodMonths
+	ps := start.AddDate(0, k*s.PeriodMonths, 0)
+	if ps.After(at) {
+		k--
+		ps = start.AddDate(0, k*s.PeriodMonths, 0)
+	}
+	return ps, ps.AddDate(0, s.PeriodMonths, 0)
+}
+
+// duesPaidInPeriod 统计成员在某缴费周期内已缴金额
+func duesPaidInPeriod(clubID, userID uint, periodStart time.Time) float64 {
+	var paid float64
+	store.DB().Model(&models.DuesPayment{}).
+		Where("club_id = ? AND user_id = ? AND period_start = ?", clubID, userID, periodStart.Format("2006-01-02")).
+		Select("COALESCE(SUM(amount), 0)").Scan(&paid)
+	return paid
+}
+
+// duesBlocked 社团开启了未缴费禁止报名且成员本期未缴清时返回 true
+func duesBlocked(clubID, userID uint, now time.Time) bool {
+	var s models.DuesSchedule
+	if err := store.DB().Where("club_id = ?", clubID).First(&s).Error; err != nil || !s.BlockRegistration {
+		return false
+	}
+	start, _ := duesPeriod(s, now)
+	if now.Before(start) {
+		return false
+	}
+	return duesPaidInPeriod(clubID, userID, start) < s.Amount
+}
+
+// @Summary 社费标准（负责人）
+// @Tags 社费
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/dues/schedule [get]
+func GetDuesSchedule(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageDues) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var s models.DuesSchedule
+	if err := store.DB().Where("club_id = ?", clubID).First(&s).Error; err != nil {
+		c.JSON(http.StatusOK, response.Success(nil))
+		return
+	}
+	start, end := duesPeriod(s, time.Now())
+	c.JSON(http.StatusOK, response.Success(map[string]any{"schedule": s, "period_start": start, "period_end": end}))
+}
+
+// @Summary 设置社费标准（负责人）
+// @Tags 社费
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body DuesScheduleReq true "社费标准"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/dues/schedule [put]
+func UpdateDuesSchedule(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageDues) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req DuesScheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "金额需大于0"))
+		return
+	}
+	if req.PeriodMonths <= 0 || req.PeriodMonths > 48 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "缴费周期范围为 1-48 个月"))
+		return
+	}
+	start, err := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "日期格式应为 YYYY-MM-DD"))
+		return
+	}
+	var s models.DuesSchedule
+	store.DB().Where("club_id = ?", clubID).First(&s)
+	s.ClubID, s.Amount, s.PeriodMonths, s.StartDate, s.BlockRegistration = uint(clubID), round2(req.Amount), req.PeriodMonths, start, req.BlockRegistration
+	if err := store.DB().Save(&s).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "保存失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "社费管理", fmt.Sprintf("设置社费 %.2f 元/%d 个月，未缴禁止报名 %v", s.Amount, s.PeriodMonths, s.BlockRegistration), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(s))
+}
+
+// @Summary 取消社费标准（负责人，已有缴费记录保留）
+// @Tags 社费
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/dues/schedule [delete]
+func DeleteDuesSchedule(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageDues) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	if err := store.DB().Where("club_id = ?", clubID).Delete(&models.DuesSchedule{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "社费管理", "取消社费标准", uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 登记社费缴纳（负责人）
+// @Description 默认计入缴费时间所在的缴费周期，补缴往期时通过 period_start 指定周期
+// @Tags 社费
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body DuesPaymentReq true "缴费信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/dues/payments [post]
+func RecordDuesPayment(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageDues) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req DuesPaymentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.Amount <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "金额需大于0"))
+		return
+	}
+	switch req.Method {
+	case "cash", "wechat", "alipay", "transfer", "other":
+	default:
+		c.JSON(http.StatusBadRequest, response.Error(400, "缴费方式仅支持 cash/wechat/alipay/transfer/other"))
+		return
+	}
+	var s models.DuesSchedule
+	if err := store.DB().Where("club_id = ?", clubID).First(&s).Error; err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "社团未设置社费标准"))
+		return
+	}
+	var m models.Membership
+	if err := store.DB().Preload("User").Where("id = ? AND club_id = ?", req.MembershipID, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
+		return
+	}
+	paidAt := time.Now()
+	if req.PaidAt != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", req.PaidAt, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "时间格式应为 YYYY-MM-DD HH:MM:SS"))
+			return
+		}
+		paidAt = t
+	}
+	if req.ReceiptNo == "" {
+		req.ReceiptNo = fmt.Sprintf("D%d-%s-%d", clubID, paidAt.Format("20060102150405"), m.UserID)
+	}
+	var cnt int64
+	store.DB().Model(&models.DuesPayment{}).Where("club_id = ? AND receipt_no = ?", clubID, req.ReceiptNo).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "收据编号已存在"))
+		return
+	}
+	start, end := duesPeriod(s, paidAt)
+	if req.PeriodStart != "" {
+		t, err := time.ParseInLocation("2006-01-02", req.PeriodStart, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "周期开始日期格式应为 YYYY-MM-DD"))
+			return
+		}
+		start, end = duesPeriod(s, t)
+		if start.Format("2006-01-02") != req.PeriodStart {
+			c.JSON(http.StatusBadRequest, response.Error(400, fmt.Sprintf("%s 不是缴费周期的开始日期，最近的周期从 %s 开始", req.PeriodStart, start.Format("2006-01-02"))))
+			return
+		}
+	}
+	p := models.DuesPayment{
+		MembershipID:   m.ID,
+		ClubID:         uint(clubID),
+		UserID:         m.UserID,
+		Amount:         round2(req.Amount),
+		Method:         req.Method,
+		ReceiptNo:      req.ReceiptNo,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		PaidAt:         paidAt,
+		RecordedBy:     u.ID,
+		RecordedByName: u.Name,
+		Note:           req.Note,
+	}
+	if err := store.DB().Create(&p).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "登记失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "社费管理", fmt.Sprintf("登记 %s 社费 %.2f 元，收据 %s", m.User.Name, p.Amount, p.ReceiptNo), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(p))
+}
+
+// @Summary 社费缴纳记录（负责人）
+// @Tags 社费
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param userId query int false "成员用户ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/dues/payments [get]
+func ListDuesPayments(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageDues) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	q := store.DB().Model(&models.DuesPayment{}).Where("club_id = ?", clubID).Preload("User")
+	if v := c.Query("userId"); v != "" {
+		q = q.Where("user_id = ?", v)
+	}
+	var list []models.DuesPayment
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("paid_at DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 本期欠费成员（负责人）
+// @Tags 社费
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/dues/outstanding [get]
+func ListOutstandingDues(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageDues) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var s models.DuesSchedule
+	if err := store.DB().Where("club_id = ?", clubID).First(&s).Error; err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "社团未设置社费标准"))
+		return
+	}
+	start, end := duesPeriod(s, time.Now())
+	var members []models.Membership
+	if err := store.DB().Preload("User").Where("club_id = ? AND status = ?", clubID, "approved").Find(&members).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	var rows []struct {
+		MembershipID uint
+		Paid         float64
+	}
+	store.DB().Model(&models.DuesPayment{}).Select("membership_id, SUM(amount) AS paid").
+		Where("club_id = ? AND period_start = ?", clubID, start.Format("2006-01-02")).
+		Group("membership_id").Scan(&rows)
+	paid := make(map[uint]float64, len(rows))
+	for _, r := range rows {
+		paid[r.MembershipID] = r.Paid
+	}
+	list := make([]OutstandingDues, 0)
+	var total float64
+	for _, m := range members {
+		if p := paid[m.ID]; p < s.Amount {
+			list = append(list, OutstandingDues{
+				MembershipID: m.ID, UserID: m.UserID, Name: m.User.Name, StudentNo: m.User.StudentNo,
+				Due: s.Amount, Paid: round2(p), Outstanding: round2(s.Amount - p),
+			})
+			total += s.Amount - p
+		}
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{
+		"period_start": start, "period_end": end, "list": list, "total_outstanding": round2(total),
+	}))
+}
+
+// @Summary 我的社费
+// @Tags 社费
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/dues/my [get]
+func MyDues(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var payments []models.DuesPayment
+	if err := store.DB().Where("user_id = ?", u.ID).Order("paid_at DESC").Find(&payments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	var schedules []models.DuesSchedule
+	store.DB().Where("club_id IN (?)", store.DB().Model(&models.Membership{}).Select("club_id").Where("user_id = ? AND status = ?", u.ID, "approved")).Find(&schedules)
+	now := time.Now()
+	status := make([]map[string]any, 0, len(schedules))
+	for _, s := range schedules {
+		start, end := duesPeriod(s, now)
+		p := duesPaidInPeriod(s.ClubID, u.ID, start)
+		status = append(status, map[string]any{
+			"club_id": s.ClubID, "period_start": start, "period_end": end,
+			"due": s.Amount, "paid": round2(p), "settled": p >= s.Amount,
+		})
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"status": status, "payments": payments}))
+}
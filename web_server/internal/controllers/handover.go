@@ -0,0 +1,455 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/membership"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HandoverReq struct {
+	ToUserID     uint   `json:"to_user_id" binding:"required"`
+	OutgoingRole string `json:"outgoing_role"` // member（默认）或 alumni（交接后退社）
+	Note         string `json:"note"`
+}
+
+type HandoverSettingsReq struct {
+	RequiresAdmin bool `json:"requires_admin"`
+}
+
+// handoverErrorMsg 交接业务错误返回 400 提示，其余为数据库错误
+func handoverErrorMsg(err error) (string, bool) {
+	for _, e := range []error{membership.ErrHandoverClosed, membership.ErrHandoverStale, membership.ErrSuccessorInvalid} {
+		if errors.Is(err, e) {
+			return e.Error(), true
+		}
+	}
+	return "", false
+}
+
+// errLeaderViaHandover 直接修改角色时不能任命或撤换社长，社长变更只能走交接流程
+const errLeaderViaHandover = "社长变更请通过社长交接办理"
+
+// touchesLeader 判断角色调整是否涉及社长，避免出现两个社长或没有社长
+func touchesLeader(fromRole, toRole string) bool {
+	return fromRole == "leader" || toRole == "leader"
+}
+
+func handoverLogContent(h *models.LeaderHandover) string {
+	outgoing := "转为普通成员"
+	if h.OutgoingRole == "alumni" {
+		outgoing = "退社成为校友"
+	}
+	return fmt.Sprintf("社长由用户 %d 交接给用户 %d，原社长%s", h.FromUserID, h.ToUserID, outgoing)
+}
+
+// @Summary 发起社长交接（社长）
+// @Description 提名一名正式成员为继任者，继任者接受后完成交接；社团要求时还需管理员确认
+// @Tags 负责人
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body HandoverReq true "继任者与原社长去向"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/handovers [post]
+func CreateHandover(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var req HandoverReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.OutgoingRole == "" {
+		req.OutgoingRole = "member"
+	}
+	if req.OutgoingRole != "member" && req.OutgoingRole != "alumni" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "原社长去向只能为 member 或 alumni"))
+		return
+	}
+	// 仅现任社长可发起，指导老师与管理员不代为提名
+	var from models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ? AND role = ? AND status = ?", u.ID, clubID, "leader", "approved").First(&from).Error; err != nil {
+		c.JSON(http.StatusForbidden, response.Error(403, "仅现任社长可发起交接"))
+		return
+	}
+	if req.ToUserID == u.ID {
+		c.JSON(http.StatusBadRequest, response.Error(400, "不能提名自己"))
+		return
+	}
+	var to models.Membership
+	if err := store.DB().Preload("User").Where("user_id = ? AND club_id = ? AND status = ?", req.ToUserID, clubID, "approved").First(&to).Error; err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "继任者须为社团正式成员"))
+		return
+	}
+	if to.Role == "leader" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "该成员已是社长"))
+		return
+	}
+	var cl models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
+		return
+	}
+	var open int64
+	store.DB().Model(&models.LeaderHandover{}).Where("club_id = ? AND status IN ?", clubID, membership.OpenHandoverStatuses).Count(&open)
+	if open > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "已有进行中的交接，请先撤销"))
+		return
+	}
+	h := models.LeaderHandover{
+		ClubID:       uint(clubID),
+		FromUserID:   u.ID,
+		ToUserID:     req.ToUserID,
+		OutgoingRole: req.OutgoingRole,
+		Note:         req.Note,
+		RequireAdmin: cl.HandoverRequiresAdmin,
+		Status:       "pending",
+	}
+	if err := store.DB().Create(&h).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "发起失败"))
+		return
+	}
+	store.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Notification{
+		UserID:  req.ToUserID,
+		ClubID:  uint(clubID),
+		Kind:    "leader_handover",
+		Title:   "社长交接邀请",
+		Content: fmt.Sprintf("%s 提名您接任「%s」社长，请确认是否接受。", u.Name, cl.Name),
+		RefKey:  fmt.Sprintf("handover:%d", h.ID),
+	})
+	RecordLog(u.ID, u.Name, "社长交接", fmt.Sprintf("提名 %s 接任社长", to.User.Name), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(h))
+}
+
+// @Summary 社团交接记录（负责人）
+// @Tags 负责人
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/handovers [get]
+func ListClubHandovers(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var list []models.LeaderHandover
+	if err := store.DB().Preload("FromUser").Preload("ToUser").Where("club_id = ?", clubID).Order("id DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 撤销社长交接（发起人或管理员）
+// @Tags 负责人
+// @Produce json
+// @Param id path int true "交接ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/handovers/{id}/cancel [post]
+func CancelHandover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var h models.LeaderHandover
+	if err := store.DB().Where("id = ?", id).First(&h).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "交接不存在"))
+		return
+	}
+	if h.FromUserID != u.ID && !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	res := store.DB().Model(&models.LeaderHandover{}).Where("id = ? AND status IN ?", h.ID, membership.OpenHandoverStatuses).Update("status", "cancelled")
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "交接已结束"))
+		return
+	}
+	h.Status = "cancelled"
+	RecordLog(u.ID, u.Name, "社长交接", fmt.Sprintf("撤销交接 %d", h.ID), h.ClubID)
+	c.JSON(http.StatusOK, response.Success(h))
+}
+
+// @Summary 我收到的社长交接
+// @Tags 成员
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/handovers/my [get]
+func MyHandovers(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var list []models.LeaderHandover
+	if err := store.DB().Preload("Club").Preload("FromUser").Where("to_user_id = ?", u.ID).Order("id DESC").Limit(50).Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 接受社长交接（继任者）
+// @Description 社团无需管理员确认时立即完成交接，否则进入待管理员确认
+// @Tags 成员
+// @Produce json
+// @Param id path int true "交接ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/handovers/{id}/accept [post]
+func AcceptHandover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var h models.LeaderHandover
+	if err := store.DB().Where("id = ? AND to_user_id = ?", id, u.ID).First(&h).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "交接不存在"))
+		return
+	}
+	var completed bool
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		var err error
+		completed, err = membership.AcceptHandover(tx, &h, time.Now())
+		return err
+	})
+	if err != nil {
+		if msg, ok := handoverErrorMsg(err); ok {
+			c.JSON(http.StatusBadRequest, response.Error(400, msg))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if completed {
+		RecordLog(u.ID, u.Name, "社长交接", handoverLogContent(&h), h.ClubID)
+	} else {
+		RecordLog(u.ID, u.Name, "社长交接", "接受社长交接，待管理员确认", h.ClubID)
+	}
+	c.JSON(http.StatusOK, response.Success(h))
+}
+
+// @Summary 拒绝社长交接（继任者）
+// @Tags 成员
+// @Produce json
+// @Param id path int true "交接ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/handovers/{id}/decline [post]
+func DeclineHandover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var h models.LeaderHandover
+	if err := store.DB().Where("id = ? AND to_user_id = ?", id, u.ID).First(&h).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "交接不存在"))
+		return
+	}
+	res := store.DB().Model(&models.LeaderHandover{}).Where("id = ? AND status = ?", h.ID, "pending").Update("status", "declined")
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "交接已结束"))
+		return
+	}
+	h.Status = "declined"
+	RecordLog(u.ID, u.Name, "社长交接", "拒绝接任社长", h.ClubID)
+	c.JSON(http.StatusOK, response.Success(h))
+}
+
+// @Summary 社长交接列表（管理员）
+// @Tags 管理员
+// @Produce json
+// @Param status query string false "状态：accepted 为待确认"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/handovers [get]
+func ListHandovers(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	q := store.DB().Model(&models.LeaderHandover{}).Preload("Club").Preload("FromUser").Preload("ToUser")
+	if s := c.Query("status"); s != "" {
+		q = q.Where("status = ?", s)
+	}
+	var list []models.LeaderHandover
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 确认社长交接（管理员）
+// @Tags 管理员
+// @Produce json
+// @Param id path int true "交接ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/handovers/{id}/confirm [post]
+func ConfirmHandover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var h models.LeaderHandover
+	if err := store.DB().Where("id = ?", id).First(&h).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "交接不存在"))
+		return
+	}
+	if h.Status != "accepted" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "继任者尚未接受或交接已结束"))
+		return
+	}
+	h.ConfirmedBy = u.ID
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		return membership.CompleteHandover(tx, &h, time.Now())
+	})
+	if err != nil {
+		if msg, ok := handoverErrorMsg(err); ok {
+			c.JSON(http.StatusBadRequest, response.Error(400, msg))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "社长交接", handoverLogContent(&h), h.ClubID)
+	c.JSON(http.StatusOK, response.Success(h))
+}
+
+// @Summary 驳回社长交接（管理员）
+// @Tags 管理员
+// @Produce json
+// @Param id path int true "交接ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/handovers/{id}/reject [post]
+func RejectHandover(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var h models.LeaderHandover
+	if err := store.DB().Where("id = ?", id).First(&h).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "交接不存在"))
+		return
+	}
+	res := store.DB().Model(&models.LeaderHandover{}).Where("id = ? AND status IN ?", h.ID, membership.OpenHandoverStatuses).
+		Updates(map[string]any{"status": "rejected", "confirmed_by": u.ID})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "交接已结束"))
+		return
+	}
+	h.Status = "rejected"
+	h.ConfirmedBy = u.ID
+	RecordLog(u.ID, u.Name, "社长交接", fmt.Sprintf("驳回交接 %d", h.ID), h.ClubID)
+	c.JSON(http.StatusOK, response.Success(h))
+}
+
+// @Summary 设置社长交接是否需管理员确认（管理员）
+// @Tags 管理员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body HandoverSettingsReq true "交接设置"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/clubs/{clubId}/handover-settings [put]
+func UpdateHandoverSettings(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req HandoverSettingsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if err := store.DB().Model(&models.Club{}).Where("id = ?", clubID).Update("handover_requires_admin", req.RequiresAdmin).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "社长交接", fmt.Sprintf("设置交接需管理员确认：%v", req.RequiresAdmin), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(req))
+}
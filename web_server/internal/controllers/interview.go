@@ -0,0 +1,467 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var (
+	errSlotFull      = errors.New("该时段已约满")
+	errInterviewDone = errors.New("面试已完成，不能改约")
+)
+
+type InterviewSlotReq struct {
+	StartAt  string `json:"start_at" binding:"required"` // YYYY-MM-DD HH:MM:SS
+	EndAt    string `json:"end_at" binding:"required"`   // YYYY-MM-DD HH:MM:SS
+	Place    string `json:"place" binding:"required"`
+	Capacity int    `json:"capacity" binding:"required"`
+}
+
+type InterviewScoreReq struct {
+	Score *int   `json:"score" binding:"required"` // 0-100
+	Notes string `json:"notes"`
+}
+
+func parseSlotReq(req InterviewSlotReq) (models.InterviewSlot, string) {
+	start, err1 := time.ParseInLocation("2006-01-02 15:04:05", req.StartAt, time.Local)
+	end, err2 := time.ParseInLocation("2006-01-02 15:04:05", req.EndAt, time.Local)
+	if err1 != nil || err2 != nil {
+		return models.InterviewSlot{}, "时间格式应为 YYYY-MM-DD HH:MM:SS"
+	}
+	if !end.After(start) {
+		return models.InterviewSlot{}, "结束时间需晚于开始时间"
+	}
+	if req.Capacity <= 0 {
+		return models.InterviewSlot{}, "容量需大于0"
+	}
+	return models.InterviewSlot{StartAt: start, EndAt: end, Place: req.Place, Capacity: req.Capacity}, ""
+}
+
+// loadLeaderSlot 读取路径中的面试时段并校验负责人权限，失败时已写入响应
+func loadLeaderSlot(c *gin.Context) (models.InterviewSlot, *models.User, bool) {
+	var slot models.InterviewSlot
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return slot, nil, false
+	}
+	if err := store.DB().Where("id = ?", id).First(&slot).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "面试时段不存在"))
+		return slot, nil, false
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, slot.ClubID, authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return slot, nil, false
+	}
+	return slot, u, true
+}
+
+// @Summary 招新面试时段列表（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "招新活动ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/campaigns/{id}/slots [get]
+func ListCampaignSlots(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var list []models.InterviewSlot
+	if err := store.DB().Where("campaign_id = ? AND club_id = ?", id, clubID).Order("start_at ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 发布面试时段（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "招新活动ID"
+// @Param payload body InterviewSlotReq true "面试时段"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/campaigns/{id}/slots [post]
+func CreateInterviewSlot(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var camp models.RecruitmentCampaign
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&camp).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "招新活动不存在"))
+		return
+	}
+	var req InterviewSlotReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	slot, msg := parseSlotReq(req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	slot.CampaignID, slot.ClubID = camp.ID, camp.ClubID
+	if err := store.DB().Create(&slot).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("发布面试时段: %s %s", slot.StartAt.Format("2006-01-02 15:04"), slot.Place), camp.ClubID)
+	c.JSON(http.StatusOK, response.Success(slot))
+}
+
+// @Summary 修改面试时段（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param id path int true "面试时段ID"
+// @Param payload body InterviewSlotReq true "面试时段"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/interview-slots/{id} [put]
+func UpdateInterviewSlot(c *gin.Context) {
+	slot, u, ok := loadLeaderSlot(c)
+	if !ok {
+		return
+	}
+	var req InterviewSlotReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	ns, msg := parseSlotReq(req)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	// 容量与已预约人数在同一条件更新中比较，避免并发预约时容量被改小
+	res := store.DB().Model(&models.InterviewSlot{}).Where("id = ? AND booked <= ?", slot.ID, ns.Capacity).
+		Updates(map[string]any{"start_at": ns.StartAt, "end_at": ns.EndAt, "place": ns.Place, "capacity": ns.Capacity})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "容量不能小于已预约人数"))
+		return
+	}
+	store.DB().Where("id = ?", slot.ID).First(&slot)
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("修改面试时段 %d", slot.ID), slot.ClubID)
+	c.JSON(http.StatusOK, response.Success(slot))
+}
+
+// @Summary 删除面试时段（负责人，仅限无人预约）
+// @Tags 成员
+// @Produce json
+// @Param id path int true "面试时段ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/interview-slots/{id} [delete]
+func DeleteInterviewSlot(c *gin.Context) {
+	slot, u, ok := loadLeaderSlot(c)
+	if !ok {
+		return
+	}
+	res := store.DB().Where("id = ? AND booked = 0", slot.ID).Delete(&models.InterviewSlot{})
+	if res.Error != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	if res.RowsAffected == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "已有预约，不能删除"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("删除面试时段 %d", slot.ID), slot.ClubID)
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 面试时段预约名单（负责人）
+// @Tags 成员
+// @Produce json
+// @Param id path int true "面试时段ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/interview-slots/{id}/interviews [get]
+func ListSlotInterviews(c *gin.Context) {
+	slot, _, ok := loadLeaderSlot(c)
+	if !ok {
+		return
+	}
+	var list []models.Interview
+	if err := store.DB().Where("slot_id = ?", slot.ID).Preload("User").Order("id ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 记录面试评分（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param id path int true "面试记录ID"
+// @Param payload body InterviewScoreReq true "评分与备注"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/interviews/{id}/score [post]
+func ScoreInterview(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var req InterviewScoreReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if *req.Score < 0 || *req.Score > 100 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "评分范围为 0-100"))
+		return
+	}
+	var iv models.Interview
+	if err := store.DB().Preload("User").Where("id = ?", id).First(&iv).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "面试记录不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, iv.ClubID, authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	now := time.Now()
+	iv.Score, iv.Notes, iv.InterviewerID, iv.InterviewerName, iv.ScoredAt = req.Score, req.Notes, u.ID, u.Name, &now
+	if err := store.DB().Model(&iv).Updates(map[string]any{"score": *req.Score, "notes": req.Notes, "interviewer_id": u.ID, "interviewer_name": u.Name, "scored_at": now}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "保存失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("面试评分 %s: %d", iv.User.Name, *req.Score), iv.ClubID)
+	c.JSON(http.StatusOK, response.Success(iv))
+}
+
+// pendingMembership 返回当前用户在社团的待审核申请
+func pendingMembership(userID, clubID uint) (*models.Membership, bool) {
+	var m models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ? AND status = ?", userID, clubID, "pending").First(&m).Error; err != nil {
+		return nil, false
+	}
+	return &m, true
+}
+
+// @Summary 可预约的面试时段
+// @Tags 学生
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/clubs/{clubId}/interview-slots [get]
+func ListOpenInterviewSlots(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	m, ok := pendingMembership(u.ID, uint(clubID))
+	if !ok {
+		c.JSON(http.StatusBadRequest, response.Error(400, "没有待审核的入社申请"))
+		return
+	}
+	q := store.DB().Where("club_id = ? AND start_at > ?", clubID, time.Now())
+	if m.CampaignID != nil {
+		q = q.Where("campaign_id = ?", *m.CampaignID)
+	}
+	var list []models.InterviewSlot
+	if err := q.Order("start_at ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 我的面试
+// @Tags 学生
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/clubs/{clubId}/interview [get]
+func MyInterview(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var iv models.Interview
+	if err := store.DB().Preload("Slot").Where("user_id = ? AND club_id = ?", u.ID, clubID).Order("id DESC").First(&iv).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "尚未预约面试"))
+		return
+	}
+	// 评分与备注仅供负责人查看
+	iv.Score, iv.Notes = nil, ""
+	c.JSON(http.StatusOK, response.Success(iv))
+}
+
+// @Summary 预约或改约面试时段
+// @Tags 学生
+// @Produce json
+// @Param id path int true "面试时段ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/interview-slots/{id}/book [post]
+func BookInterviewSlot(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var slot models.InterviewSlot
+	if err := store.DB().Where("id = ?", id).First(&slot).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "面试时段不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	m, ok := pendingMembership(u.ID, slot.ClubID)
+	if !ok {
+		c.JSON(http.StatusBadRequest, response.Error(400, "没有待审核的入社申请"))
+		return
+	}
+	if m.CampaignID != nil && *m.CampaignID != slot.CampaignID {
+		c.JSON(http.StatusBadRequest, response.Error(400, "该时段不属于本次招新"))
+		return
+	}
+	if !slot.StartAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "该时段已开始"))
+		return
+	}
+	var iv models.Interview
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		found := tx.Where("membership_id = ?", m.ID).First(&iv).Error == nil
+		if found {
+			if iv.SlotID == slot.ID {
+				return nil
+			}
+			if iv.ScoredAt != nil {
+				return errInterviewDone
+			}
+		}
+		// 条件更新占用名额，容量已满时不更新
+		res := tx.Model(&models.InterviewSlot{}).Where("id = ? AND booked < capacity", slot.ID).Update("booked", gorm.Expr("booked + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errSlotFull
+		}
+		if found {
+			if err := tx.Model(&models.InterviewSlot{}).Where("id = ? AND booked > 0", iv.SlotID).Update("booked", gorm.Expr("booked - 1")).Error; err != nil {
+				return err
+			}
+			iv.SlotID = slot.ID
+			return tx.Model(&iv).Update("slot_id", slot.ID).Error
+		}
+		iv = models.Interview{MembershipID: m.ID, SlotID: slot.ID, UserID: u.ID, ClubID: slot.ClubID}
+		return tx.Create(&iv).Error
+	})
+	if err != nil {
+		if errors.Is(err, errSlotFull) || errors.Is(err, errInterviewDone) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "预约失败"))
+		return
+	}
+	iv.Slot = slot
+	c.JSON(http.StatusOK, response.Success(iv))
+}
+
+// @Summary 取消面试预约
+// @Tags 学生
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/clubs/{clubId}/interview [delete]
+func CancelInterview(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	m, ok := pendingMembership(u.ID, uint(clubID))
+	if !ok {
+		c.JSON(http.StatusBadRequest, response.Error(400, "没有待审核的入社申请"))
+		return
+	}
+	var iv models.Interview
+	if err := store.DB().Where("membership_id = ?", m.ID).First(&iv).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "尚未预约面试"))
+		return
+	}
+	if iv.ScoredAt != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "面试已完成，不能取消"))
+		return
+	}
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&iv).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.InterviewSlot{}).Where("id = ? AND booked > 0", iv.SlotID).Update("booked", gorm.Expr("booked - 1")).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "取消失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(nil))
+}
@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"web_server/config"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/membership"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 邀请码字符集，去掉了易混淆的 0/O/1/I
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var (
+	errInviteInvalid = errors.New("邀请码无效")
+	errInviteUsedUp  = errors.New("邀请码已达使用上限")
+	errAlreadyMember = errors.New("已是社团成员")
+	errInviteReused  = errors.New("已使用过该邀请码")
+)
+
+type InviteCodeReq struct {
+	Role        string `json:"role"` // member, advisor，默认 member
+	AutoApprove bool   `json:"auto_approve"`
+	MaxUses     int    `json:"max_uses"`   // 0 表示不限次数
+	ExpiresAt   string `json:"expires_at"` // YYYY-MM-DD HH:MM:SS，为空表示长期有效
+}
+
+type RedeemInviteReq struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type InviteCodeItem struct {
+	models.InviteCode
+	Link   string `json:"link"`
+	Active bool   `json:"active"`
+}
+
+func newInviteCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = inviteAlphabet[int(b[i])%len(inviteAlphabet)]
+	}
+	return string(b), nil
+}
+
+func inviteItem(ic models.InviteCode, now time.Time) InviteCodeItem {
+	active := ic.RevokedAt == nil && (ic.ExpiresAt == nil || ic.ExpiresAt.After(now)) && (ic.MaxUses == 0 || ic.Uses < ic.MaxUses)
+	return InviteCodeItem{InviteCode: ic, Link: fmt.Sprintf("%s/join?code=%s", config.Default().Server.BaseURL, ic.Code), Active: active}
+}
+
+// @Summary 邀请码列表（负责人，含使用次数）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/invites [get]
+func ListInviteCodes(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var list []models.InviteCode
+	if err := store.DB().Where("club_id = ?", clubID).Order("id DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	now := time.Now()
+	items := make([]InviteCodeItem, 0, len(list))
+	for _, ic := range list {
+		items = append(items, inviteItem(ic, now))
+	}
+	c.JSON(http.StatusOK, response.Success(items))
+}
+
+// @Summary 创建邀请码（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body InviteCodeReq true "邀请码设置"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/invites [post]
+func CreateInviteCode(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req InviteCodeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.Role == "" {
+		req.Role = "member"
+	}
+	switch req.Role {
+	case "member":
+	case "advisor":
+		// 指导老师邀请码仅社长或管理员可创建
+		var callerM models.Membership
+		if !authz.IsAdmin(u) && (store.DB().Where("user_id = ? AND club_id = ?", u.ID, clubID).First(&callerM).Error != nil || callerM.Role != "leader") {
+			c.JSON(http.StatusForbidden, response.Error(403, "无权限创建该角色的邀请码"))
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, response.Error(400, "邀请角色仅支持 member/advisor"))
+		return
+	}
+	if req.MaxUses < 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "使用次数不能为负数"))
+		return
+	}
+	ic := models.InviteCode{ClubID: uint(clubID), Role: req.Role, AutoApprove: req.AutoApprove, MaxUses: req.MaxUses, CreatedBy: u.ID}
+	if req.ExpiresAt != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", req.ExpiresAt, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "时间格式应为 YYYY-MM-DD HH:MM:SS"))
+			return
+		}
+		if !t.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, response.Error(400, "过期时间需晚于当前时间"))
+			return
+		}
+		ic.ExpiresAt = &t
+	}
+	// 邀请码冲突时重新生成
+	for i := 0; i < 5; i++ {
+		code, err := newInviteCode()
+		if err != nil {
+			break
+		}
+		ic.Code = code
+		if err = store.DB().Create(&ic).Error; err == nil {
+			break
+		}
+		ic.ID = 0
+	}
+	if ic.ID == 0 {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "邀请码管理", fmt.Sprintf("创建邀请码 %s（角色 %s，自动通过 %v，上限 %d）", ic.Code, ic.Role, ic.AutoApprove, ic.MaxUses), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(inviteItem(ic, time.Now())))
+}
+
+// @Summary 撤销邀请码（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "邀请码ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/invites/{id}/revoke [post]
+func RevokeInviteCode(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var ic models.InviteCode
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&ic).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "邀请码不存在"))
+		return
+	}
+	if ic.RevokedAt == nil {
+		now := time.Now()
+		ic.RevokedAt = &now
+		if err := store.DB().Model(&ic).Update("revoked_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+			return
+		}
+		RecordLog(u.ID, u.Name, "邀请码管理", fmt.Sprintf("撤销邀请码 %s", ic.Code), uint(clubID))
+	}
+	c.JSON(http.StatusOK, response.Success(inviteItem(ic, time.Now())))
+}
+
+// @Summary 邀请码使用记录（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "邀请码ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/invites/{id}/redemptions [get]
+func ListInviteRedemptions(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var ic models.InviteCode
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&ic).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "邀请码不存在"))
+		return
+	}
+	var list []models.InviteRedemption
+	if err := store.DB().Where("code_id = ?", ic.ID).Preload("User").Order("id DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 使用邀请码加入社团
+// @Description 邀请码由负责人发放，不受招新期限制；按邀请码设置直接通过或进入待审核
+// @Tags 学生
+// @Accept json
+// @Produce json
+// @Param payload body RedeemInviteReq true "邀请码"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/invites/redeem [post]
+func RedeemInviteCode(c *gin.Context) {
+	var req RedeemInviteReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	now := time.Now()
+	var m models.Membership
+	err := store.DB().Transaction(func(tx *gorm.DB) error {
+		var ic models.InviteCode
+		if err := tx.Where("code = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", code, now).First(&ic).Error; err != nil {
+			return errInviteInvalid
+		}
+		var cl models.Club
+		if err := tx.Where("id = ? AND status = ?", ic.ClubID, "approved").First(&cl).Error; err != nil {
+			return errInviteInvalid
+		}
+		var used int64
+		tx.Model(&models.InviteRedemption{}).Where("code_id = ? AND user_id = ?", ic.ID, u.ID).Count(&used)
+		if used > 0 {
+			return errInviteReused
+		}
+		found := tx.Where("user_id = ? AND club_id = ?", u.ID, ic.ClubID).First(&m).Error == nil
+		if found && (m.Status == "approved" || (m.Status == "pending" && !ic.AutoApprove)) {
+			return errAlreadyMember
+		}
+		status := "pending"
+		if ic.AutoApprove {
+			status = "approved"
+		}
+		if !found {
+			m = models.Membership{UserID: u.ID, ClubID: ic.ClubID}
+		}
+		// 条件更新占用次数，并发使用时不会超过上限
+		res := tx.Model(&models.InviteCode{}).Where("id = ? AND (max_uses = 0 OR uses < max_uses)", ic.ID).Update("uses", gorm.Expr("uses + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errInviteUsedUp
+		}
+		m.Role = ic.Role
+		m.ExpiresAt = nil
+		if status == "approved" && !membership.IsLeaderRole(ic.Role) {
+			m.ExpiresAt = membership.ExpiryFrom(cl, now)
+		}
+		if err := membership.TransitWithReason(tx, &m, status, u.ID, now, "使用邀请码 "+ic.Code); err != nil {
+			return err
+		}
+		return tx.Create(&models.InviteRedemption{CodeID: ic.ID, UserID: u.ID, MembershipID: m.ID, Status: status}).Error
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errInviteInvalid), errors.Is(err, errInviteUsedUp), errors.Is(err, errAlreadyMember), errors.Is(err, errInviteReused), membership.IsStateError(err):
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, response.Error(500, "加入失败"))
+		}
+		return
+	}
+	if m.Status == "approved" {
+		RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("通过邀请码加入社团（%s）", m.Role), m.ClubID)
+	}
+	c.JSON(http.StatusOK, response.Success(m))
+}
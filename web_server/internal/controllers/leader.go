@@ -4,21 +4,25 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 	"web_server/db/models"
 	"web_server/internal/authz"
+	"web_server/internal/membership"
 	"web_server/internal/store"
 	"web_server/pkg/pagination"
 	"web_server/pkg/response"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // @Summary 获取当前用户管理的社团
 // @Tags 负责人
 // @Produce json
+// @Param userId path int true "用户ID（以当前登录用户为准）"
 // @Security Bearer
 // @Success 200 {object} response.Body
-// @Router /leader/clubs [get]
+// @Router /leader/users/{userId}/clubs [get]
 func GetUserLeaderClubs(c *gin.Context) {
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
@@ -41,7 +45,7 @@ func GetUserLeaderClubs(c *gin.Context) {
 	} else {
 		// 查找担任leader或advisor的社团
 		var memberships []models.Membership
-		store.DB().Where("user_id = ? AND role IN ?", u.ID, []string{"leader", "advisor"}).Find(&memberships)
+		store.DB().Where("user_id = ? AND role IN ? AND status = ?", u.ID, []string{"leader", "advisor"}, "approved").Find(&memberships)
 
 		clubIDs := make([]uint, 0)
 		roleMap := make(map[uint]string)
@@ -67,13 +71,13 @@ func GetUserLeaderClubs(c *gin.Context) {
 // @Param clubId path int true "社团ID"
 // @Security Bearer
 // @Success 200 {object} response.Body
-// @Router /leader/clubs/{clubId}/leaders [get]
+// @Router /leader/clubs/{clubId}/users [get]
 func GetClubLeaders(c *gin.Context) {
 	clubIDStr := c.Param("clubId")
 	clubID, _ := strconv.Atoi(clubIDStr)
 
 	var members []models.Membership
-	if err := store.DB().Preload("User").Where("club_id = ? AND role IN ?", clubID, []string{"leader", "advisor"}).Find(&members).Error; err != nil {
+	if err := store.DB().Preload("User").Where("club_id = ? AND role IN ? AND status = ?", clubID, []string{"leader", "advisor"}, "approved").Find(&members).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
 		return
 	}
@@ -81,10 +85,12 @@ func GetClubLeaders(c *gin.Context) {
 }
 
 type SetRoleReq struct {
-	Role string `json:"role" binding:"required"` // member, leader, advisor
+	Role   string `json:"role" binding:"required"` // member, leader, advisor 或社团自定义角色编码
+	Reason string `json:"reason"`                  // 调整原因，记入成员变更记录
 }
 
 // @Summary 负责人设定成员角色
+// @Description 不能任命或撤换社长，社长变更请发起社长交接
 // @Tags 负责人
 // @Accept json
 // @Produce json
@@ -93,7 +99,7 @@ type SetRoleReq struct {
 // @Param payload body SetRoleReq true "角色信息"
 // @Security Bearer
 // @Success 200 {object} response.Body
-// @Router /leader/clubs/{clubId}/members/{userId}/role [put]
+// @Router /leader/clubs/{clubId}/members/{userId}/role [post]
 func SetMemberRoleByLeader(c *gin.Context) {
 	clubIDStr := c.Param("clubId")
 	userIDStr := c.Param("userId")
@@ -108,9 +114,8 @@ func SetMemberRoleByLeader(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
 		return
 	}
-	switch req.Role {
-	case "member", "leader", "advisor":
-	default:
+	newRole, ok := authz.FindClubRole(uint(clubID), req.Role)
+	if !ok {
 		c.JSON(http.StatusBadRequest, response.Error(400, "非法角色"))
 		return
 	}
@@ -119,45 +124,46 @@ func SetMemberRoleByLeader(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在该社团"))
 		return
 	}
+	if touchesLeader(m.Role, req.Role) {
+		c.JSON(http.StatusBadRequest, response.Error(400, errLeaderViaHandover))
+		return
+	}
 
-	// 权限检查：除了管理员，普通负责人（社长/管理员）需要检查级别
+	// 权限检查：除了管理员，需要有角色管理权限，且只能管理权限低于自己的成员
 	if !authz.IsAdmin(user) {
+		if !authz.HasClubPermission(user, uint(clubID), authz.PermManageRoles) {
+			c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+			return
+		}
 		var callerM models.Membership
 		if err := store.DB().Where("user_id = ? AND club_id = ?", user.ID, clubID).First(&callerM).Error; err != nil {
 			c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 			return
 		}
 
-		getRoleLevel := func(role string) int {
-			switch role {
-			case "leader":
-				return 3
-			case "advisor":
-				return 2
-			default:
-				return 1
-			}
-		}
-
-		myLevel := getRoleLevel(callerM.Role)
-		targetLevel := getRoleLevel(m.Role)
-		newLevel := getRoleLevel(req.Role)
-
 		// 不能修改同级或上级成员的权限（除了自己）
-		if targetLevel >= myLevel && user.ID != uint(userID) {
+		if user.ID != uint(userID) && !authz.Outranks(uint(clubID), callerM.Role, m.Role) {
 			c.JSON(http.StatusForbidden, response.Error(403, "权限不足：不能修改同级或上级成员的权限"))
 			return
 		}
 
 		// 不能赋予比自己更高的权限
-		if newLevel > myLevel {
+		if !authz.CanGrant(uint(clubID), callerM.Role, newRole) {
 			c.JSON(http.StatusForbidden, response.Error(403, "权限不足：不能赋予比自己更高的权限"))
 			return
 		}
 	}
 
+	fromRole := m.Role
 	m.Role = req.Role
-	if err := store.DB().Save(&m).Error; err != nil {
+	membership.NoteRole(&m)
+	err := store.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&m).Error; err != nil {
+			return err
+		}
+		return membership.RecordRoleChange(tx, &m, fromRole, user.ID, req.Reason)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
 		return
 	}
@@ -177,7 +183,7 @@ func ListPendingMembershipsOld(c *gin.Context) {
 	clubID, _ := strconv.Atoi(c.Param("clubId"))
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -195,6 +201,7 @@ func ListPendingMembershipsOld(c *gin.Context) {
 // @Produce json
 // @Param clubId path int true "社团ID"
 // @Param id path int true "成员关系ID"
+// @Param payload body MembershipReasonReq false "审批说明"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // ApproveMembershipOld (Renamed due to duplication)
@@ -204,7 +211,7 @@ func ApproveMembershipOld(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -213,11 +220,19 @@ func ApproveMembershipOld(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
 		return
 	}
-	m.Status = "approved"
-	if err := store.DB().Save(&m).Error; err != nil {
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+	msg, err := approveApplication(store.DB(), &m, reason, u.ID, time.Now())
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
 		return
 	}
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
 	RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("批准成员 %d 加入社团", m.UserID), uint(clubID))
 	c.JSON(http.StatusOK, response.Success(m))
 }
@@ -237,7 +252,7 @@ func RejectMembershipOld(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -246,8 +261,11 @@ func RejectMembershipOld(c *gin.Context) {
 		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
 		return
 	}
-	m.Status = "rejected"
-	if err := store.DB().Save(&m).Error; err != nil {
+	if err := rejectApplication(store.DB(), &m, "", u.ID, time.Now()); err != nil {
+		if membership.IsStateError(err) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
 		return
 	}
@@ -268,7 +286,7 @@ func ListClubMembersLegacy(c *gin.Context) {
 	clubID, _ := strconv.Atoi(c.Param("clubId"))
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -308,9 +326,11 @@ func DissolveClubOld(c *gin.Context) {
 
 // @Summary 踢出成员
 // @Tags 负责人
+// @Accept json
 // @Produce json
 // @Param clubId path int true "社团ID"
 // @Param userId path int true "用户ID"
+// @Param payload body MembershipReasonReq false "移出原因"
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /leader/clubs/{clubId}/members/{userId} [delete]
@@ -328,20 +348,6 @@ func KickMember(c *gin.Context) {
 		return
 	}
 
-	// Permission Logic
-	getRoleLevel := func(role string) int {
-		switch role {
-		case "leader":
-			return 3
-		case "advisor":
-			return 2
-		default:
-			return 1
-		}
-	}
-
-	targetLevel := getRoleLevel(targetM.Role)
-
 	// Special rule: Leader cannot be kicked by anyone (even Admin) via this interface
 	if targetM.Role == "leader" {
 		c.JSON(http.StatusForbidden, response.Error(403, "无法踢出社长"))
@@ -351,28 +357,40 @@ func KickMember(c *gin.Context) {
 	if authz.IsAdmin(caller) {
 		// Admin can kick anyone except leader (handled above)
 	} else {
-		// Club Leader/Advisor checks
+		// 需要成员管理权限，且只能踢出权限低于自己的成员
+		if !authz.HasClubPermission(caller, uint(clubID), authz.PermManageMembers) {
+			c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+			return
+		}
 		var callerM models.Membership
 		if err := store.DB().Where("user_id = ? AND club_id = ?", caller.ID, clubID).First(&callerM).Error; err != nil {
 			c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 			return
 		}
-
-		myLevel := getRoleLevel(callerM.Role)
-
-		// Must have higher level than target
-		if myLevel <= targetLevel {
+		if !authz.Outranks(uint(clubID), callerM.Role, targetM.Role) {
 			c.JSON(http.StatusForbidden, response.Error(403, "权限不足：只能踢出权限低于自己的成员"))
 			return
 		}
 	}
 
-	// Delete membership
-	if err := store.DB().Delete(&targetM).Error; err != nil {
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+	// 保留成员记录并标记为 kicked，冷却期内不能再次申请
+	if err := membership.TransitWithReason(store.DB(), &targetM, "kicked", caller.ID, time.Now(), reason); err != nil {
+		if membership.IsStateError(err) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
 		return
 	}
 
-	RecordLog(caller.ID, caller.Name, "修改权限", fmt.Sprintf("将成员 %d 踢出社团", userID), uint(clubID))
+	content := fmt.Sprintf("将成员 %d 踢出社团", userID)
+	if reason != "" {
+		content += "，原因：" + reason
+	}
+	RecordLog(caller.ID, caller.Name, "修改权限", content, uint(clubID))
 	c.JSON(http.StatusOK, response.Success(nil))
 }
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 考勤列表旁展示的请假记录上限
+const maxLeavesInAttendanceView = 100
+
+type LeaveReq struct {
+	ClubID     uint   `json:"club_id"`
+	ActivityID uint   `json:"activity_id"`             // 活动请假时填写，优先于 club_id
+	LeaveDate  string `json:"leave_date"`              // 例会请假日期 YYYY-MM-DD
+	Type       string `json:"type" binding:"required"` // sick, personal, official, other
+	Reason     string `json:"reason" binding:"required"`
+	Attachment string `json:"attachment"` // 附件图片地址，通过 /upload/image 上传
+}
+
+type LeaveReviewReq struct {
+	Note string `json:"note"`
+}
+
+// approvedLeaves 查询已批准的请假记录，供考勤列表旁展示
+func approvedLeaves(q *gorm.DB) []models.LeaveRequest {
+	var list []models.LeaveRequest
+	q.Where("leave_requests.status = ?", "approved").Preload("User").Preload("Club").Preload("Activity").
+		Order("leave_requests.leave_date DESC").Limit(maxLeavesInAttendanceView).Find(&list)
+	return list
+}
+
+// @Summary 提交请假申请
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param payload body LeaveReq true "请假信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/leave-requests [post]
+func CreateLeaveRequest(c *gin.Context) {
+	var req LeaveReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	switch req.Type {
+	case "sick", "personal", "official", "other":
+	default:
+		c.JSON(http.StatusBadRequest, response.Error(400, "请假类型仅支持 sick/personal/official/other"))
+		return
+	}
+	if len([]rune(req.Reason)) > 500 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "请假原因过长"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	lr := models.LeaveRequest{UserID: u.ID, Type: req.Type, Reason: req.Reason, Attachment: req.Attachment, Status: "pending"}
+	if req.ActivityID > 0 {
+		var act models.Activity
+		if err := store.DB().Where("id = ?", req.ActivityID).First(&act).Error; err != nil {
+			c.JSON(http.StatusNotFound, response.Error(404, "活动不存在"))
+			return
+		}
+		aid := act.ID
+		lr.ClubID, lr.ActivityID = act.ClubID, &aid
+		lr.LeaveDate = time.Now()
+		if act.StartAt != nil {
+			lr.LeaveDate = *act.StartAt
+		}
+	} else {
+		if req.ClubID == 0 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "请选择活动或社团"))
+			return
+		}
+		d, err := time.ParseInLocation("2006-01-02", req.LeaveDate, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "日期格式应为 YYYY-MM-DD"))
+			return
+		}
+		lr.ClubID, lr.LeaveDate = req.ClubID, d
+	}
+	if !authz.IsClubMember(u.ID, lr.ClubID) {
+		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
+		return
+	}
+	dup := store.DB().Model(&models.LeaveRequest{}).Where("user_id = ? AND club_id = ? AND status IN ?", u.ID, lr.ClubID, []string{"pending", "approved"})
+	if lr.ActivityID != nil {
+		dup = dup.Where("activity_id = ?", *lr.ActivityID)
+	} else {
+		dup = dup.Where("activity_id IS NULL AND leave_date = ?", lr.LeaveDate.Format("2006-01-02"))
+	}
+	var cnt int64
+	dup.Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "已提交过请假申请"))
+		return
+	}
+	if err := store.DB().Create(&lr).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "提交失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(lr))
+}
+
+// @Summary 我的请假记录
+// @Tags 考勤
+// @Produce json
+// @Param clubId query int false "社团ID"
+// @Param status query string false "状态: pending/approved/rejected/cancelled"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/leave-requests/my [get]
+func MyLeaveRequests(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	q := store.DB().Model(&models.LeaveRequest{}).Where("user_id = ?", u.ID).Preload("Club").Preload("Activity")
+	if v := c.Query("clubId"); v != "" {
+		q = q.Where("club_id = ?", v)
+	}
+	if v := c.Query("status"); v != "" {
+		q = q.Where("status = ?", v)
+	}
+	var list []models.LeaveRequest
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 撤回请假申请（仅限待审核）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "请假ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/leave-requests/{id} [delete]
+func CancelLeaveRequest(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var lr models.LeaveRequest
+	if err := store.DB().Where("id = ? AND user_id = ?", id, u.ID).First(&lr).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "请假记录不存在"))
+		return
+	}
+	if lr.Status != "pending" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "仅待审核的申请可撤回"))
+		return
+	}
+	if err := store.DB().Model(&lr).Update("status", "cancelled").Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 社团请假申请列表（负责人）
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param status query string false "状态，默认pending"
+// @Param userId query int false "成员ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/leave-requests [get]
+func ListClubLeaveRequests(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	q := store.DB().Model(&models.LeaveRequest{}).Where("club_id = ? AND status = ?", clubID, c.DefaultQuery("status", "pending")).
+		Preload("User").Preload("Activity")
+	if v := c.Query("userId"); v != "" {
+		q = q.Where("user_id = ?", v)
+	}
+	var list []models.LeaveRequest
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("leave_date DESC, id DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 批准请假（负责人）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param id path int true "请假ID"
+// @Param payload body LeaveReviewReq false "审核备注"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/leave-requests/{id}/approve [post]
+func ApproveLeaveRequest(c *gin.Context) {
+	reviewLeaveRequest(c, "approved")
+}
+
+// @Summary 驳回请假（负责人）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param id path int true "请假ID"
+// @Param payload body LeaveReviewReq false "审核备注"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/leave-requests/{id}/reject [post]
+func RejectLeaveRequest(c *gin.Context) {
+	reviewLeaveRequest(c, "rejected")
+}
+
+func reviewLeaveRequest(c *gin.Context, status string) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var req LeaveReviewReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
+	var lr models.LeaveRequest
+	if err := store.DB().Preload("User").Where("id = ?", id).First(&lr).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "请假记录不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, lr.ClubID, authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	if lr.Status != "pending" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "该申请已处理"))
+		return
+	}
+	now := time.Now()
+	tx := store.DB().Begin()
+	if err := tx.Model(&lr).Updates(map[string]any{"status": status, "reviewer_id": u.ID, "reviewed_at": now, "review_note": req.Note}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	if status == "approved" {
+		if err := attendance.ExcuseAbsences(tx, &lr); err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+			return
+		}
+	}
+	tx.Commit()
+
+	action := "批准"
+	if status == "rejected" {
+		action = "驳回"
+	}
+	RecordLog(u.ID, u.Name, "审核请假", fmt.Sprintf("%s %s 的请假申请（%s）", action, lr.User.Name, lr.LeaveDate.Format("2006-01-02")), lr.ClubID)
+	lr.Status, lr.ReviewerID, lr.ReviewedAt, lr.ReviewNote = status, u.ID, &now, req.Note
+	c.JSON(http.StatusOK, response.Success(lr))
+}
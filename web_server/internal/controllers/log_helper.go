@@ -58,7 +58,7 @@ func ListOperationLogs(c *gin.Context) {
 
 	cu, _ := c.Get("currentUser")
 	u := cu.(*models.User)
-	if !(authz.IsAdmin(u) || authz.IsClubLeader(u.ID, uint(clubID))) {
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermViewLogs) {
 		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
 		return
 	}
@@ -0,0 +1,355 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/config"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MandatorySessionReq struct {
+	ActivityID uint   `json:"activity_id"` // 关联活动，为空时需填写起止时间
+	Title      string `json:"title"`
+	StartAt    string `json:"start_at"`                       // YYYY-MM-DD HH:MM:SS
+	EndAt      string `json:"end_at"`                         // YYYY-MM-DD HH:MM:SS
+	RosterMode string `json:"roster_mode" binding:"required"` // all, role, list
+	RosterRole string `json:"roster_role"`
+	UserIDs    []uint `json:"user_ids"`
+}
+
+type AbsenceSummary struct {
+	UserID   uint   `json:"user_id"`
+	Name     string `json:"name"`
+	Absences int64  `json:"absences"`
+	Flagged  bool   `json:"flagged"`
+}
+
+// @Summary 设置必到场次（负责人）
+// @Description 关联活动时沿用活动起止时间；名单可为全部成员、指定角色或指定成员
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body MandatorySessionReq true "场次信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/mandatory-sessions [post]
+func CreateMandatorySession(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req MandatorySessionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	s := models.MandatorySession{ClubID: uint(clubID), Title: req.Title, RosterMode: req.RosterMode, RosterRole: req.RosterRole, CreatedBy: u.ID}
+	switch req.RosterMode {
+	case "all":
+		s.RosterRole = ""
+	case "role":
+		if req.RosterRole == "" {
+			c.JSON(http.StatusBadRequest, response.Error(400, "请指定角色"))
+			return
+		}
+	case "list":
+		if len(req.UserIDs) == 0 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "请指定成员"))
+			return
+		}
+		s.RosterRole = ""
+	default:
+		c.JSON(http.StatusBadRequest, response.Error(400, "名单类型仅支持 all/role/list"))
+		return
+	}
+	if req.ActivityID > 0 {
+		var act models.Activity
+		if err := store.DB().Where("id = ? AND club_id = ?", req.ActivityID, clubID).First(&act).Error; err != nil {
+			c.JSON(http.StatusNotFound, response.Error(404, "活动不存在"))
+			return
+		}
+		if act.StartAt == nil || act.EndAt == nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "活动未设置起止时间"))
+			return
+		}
+		var cnt int64
+		store.DB().Model(&models.MandatorySession{}).Where("activity_id = ?", act.ID).Count(&cnt)
+		if cnt > 0 {
+			c.JSON(http.StatusBadRequest, response.Error(400, "该活动已设为必到"))
+			return
+		}
+		aid := act.ID
+		s.ActivityID = &aid
+		s.StartAt, s.EndAt = *act.StartAt, *act.EndAt
+		if s.Title == "" {
+			s.Title = act.Subject
+		}
+	} else {
+		start, err1 := time.ParseInLocation("2006-01-02 15:04:05", req.StartAt, time.Local)
+		end, err2 := time.ParseInLocation("2006-01-02 15:04:05", req.EndAt, time.Local)
+		if err1 != nil || err2 != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, "时间格式应为 YYYY-MM-DD HH:MM:SS"))
+			return
+		}
+		if !end.After(start) {
+			c.JSON(http.StatusBadRequest, response.Error(400, "结束时间需晚于开始时间"))
+			return
+		}
+		if s.Title == "" {
+			c.JSON(http.StatusBadRequest, response.Error(400, "请填写场次名称"))
+			return
+		}
+		s.StartAt, s.EndAt = start, end
+	}
+	tx := store.DB().Begin()
+	if err := tx.Create(&s).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	if req.RosterMode == "list" {
+		entries := make([]models.MandatoryRosterEntry, 0, len(req.UserIDs))
+		seen := map[uint]bool{}
+		for _, id := range req.UserIDs {
+			if id > 0 && !seen[id] {
+				seen[id] = true
+				entries = append(entries, models.MandatoryRosterEntry{SessionID: s.ID, UserID: id})
+			}
+		}
+		if err := tx.Create(&entries).Error; err != nil {
+			tx.Rollback()
+			c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+			return
+		}
+		s.Roster = entries
+	}
+	tx.Commit()
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("设置必到场次: %s", s.Title), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(s))
+}
+
+// @Summary 必到场次列表（负责人）
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/mandatory-sessions [get]
+func ListMandatorySessions(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var list []models.MandatorySession
+	q := store.DB().Model(&models.MandatorySession{}).Where("club_id = ?", clubID).Preload("Roster").Order("start_at DESC")
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q, pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 取消必到场次（负责人，仅限未生成缺勤的场次）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "场次ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/mandatory-sessions/{id} [delete]
+func DeleteMandatorySession(c *gin.Context) {
+	s, u, ok := loadManagedSession(c)
+	if !ok {
+		return
+	}
+	if s.ProcessedAt != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "已生成缺勤记录，不能取消"))
+		return
+	}
+	tx := store.DB().Begin()
+	if err := tx.Where("session_id = ?", s.ID).Delete(&models.MandatoryRosterEntry{}).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	if err := tx.Delete(&s).Error; err != nil {
+		tx.Rollback()
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	tx.Commit()
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("取消必到场次: %s", s.Title), s.ClubID)
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 立即生成缺勤记录（负责人，场次结束后）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "场次ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/mandatory-sessions/{id}/absences/generate [post]
+func GenerateSessionAbsences(c *gin.Context) {
+	s, _, ok := loadManagedSession(c)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	if s.EndAt.After(now) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "场次尚未结束"))
+		return
+	}
+	created, err := attendance.GenerateAbsences(&s, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "生成失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"created": created}))
+}
+
+// @Summary 场次缺勤名单（负责人）
+// @Tags 考勤
+// @Produce json
+// @Param id path int true "场次ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/mandatory-sessions/{id}/absences [get]
+func ListSessionAbsences(c *gin.Context) {
+	s, _, ok := loadManagedSession(c)
+	if !ok {
+		return
+	}
+	var list []models.Absence
+	if err := store.DB().Where("session_id = ?", s.ID).Preload("User").Order("id ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 成员缺勤统计（负责人）
+// @Description 缺勤次数达到阈值的成员 flagged 为 true
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param termId query string false "学期ID，默认当前学期，all 表示不限学期"
+// @Param flagged query bool false "仅返回被标记的成员"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/absences/summary [get]
+func ClubAbsenceSummary(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	term, ok := termFilter(c, "termId")
+	if !ok {
+		return
+	}
+	q := store.DB().Table("absences").
+		Select("absences.user_id, users.name, COUNT(*) AS absences").
+		Joins("JOIN users ON users.id = absences.user_id").
+		Where("absences.club_id = ? AND absences.status = ?", clubID, "absent")
+	if term != nil {
+		start, end := termBounds(term)
+		q = q.Joins("JOIN mandatory_sessions ON mandatory_sessions.id = absences.session_id").
+			Where("mandatory_sessions.start_at BETWEEN ? AND ?", start, end)
+	}
+	var list []AbsenceSummary
+	if err := q.Group("absences.user_id, users.name").Order("absences DESC").Scan(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	limit := config.Default().Attendance.MaxAbsences
+	onlyFlagged := c.Query("flagged") == "true"
+	out := make([]AbsenceSummary, 0, len(list))
+	for _, it := range list {
+		it.Flagged = limit > 0 && it.Absences >= int64(limit)
+		if onlyFlagged && !it.Flagged {
+			continue
+		}
+		out = append(out, it)
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": out, "threshold": limit}))
+}
+
+// @Summary 我的缺勤记录
+// @Tags 考勤
+// @Produce json
+// @Param clubId query int false "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/absences/my [get]
+func MyAbsences(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	q := store.DB().Where("user_id = ?", u.ID).Preload("Session")
+	if v := c.Query("clubId"); v != "" {
+		q = q.Where("club_id = ?", v)
+	}
+	var list []models.Absence
+	if err := q.Order("id DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// loadManagedSession 读取路径中的场次并校验负责人权限，失败时已写入响应
+func loadManagedSession(c *gin.Context) (models.MandatorySession, *models.User, bool) {
+	var s models.MandatorySession
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return s, nil, false
+	}
+	if err := store.DB().Where("id = ?", id).First(&s).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "场次不存在"))
+		return s, nil, false
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, s.ClubID, authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return s, nil, false
+	}
+	return s, u, true
+}
@@ -0,0 +1,371 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type MemberTagReq struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color"`
+}
+
+type SetMemberTagsReq struct {
+	TagIDs []uint `json:"tag_ids"` // 覆盖成员现有标签，传空数组表示清空
+}
+
+type MemberNoteReq struct {
+	Content string `json:"content" binding:"required"`
+}
+
+type MemberTagItem struct {
+	models.MemberTag
+	UsageCount int64 `json:"usage_count"`
+}
+
+// leaderMembership 解析社团与成员关系ID并校验负责人权限，失败时已写入响应
+func leaderMembership(c *gin.Context) (*models.User, *models.Membership, bool) {
+	clubID, err1 := strconv.Atoi(c.Param("clubId"))
+	id, err2 := strconv.Atoi(c.Param("id"))
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return nil, nil, false
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return nil, nil, false
+	}
+	var m models.Membership
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
+		return nil, nil, false
+	}
+	return u, &m, true
+}
+
+// @Summary 成员标签列表（负责人，含使用次数）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/tags [get]
+func ListMemberTags(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var tags []models.MemberTag
+	if err := store.DB().Where("club_id = ?", clubID).Order("id ASC").Find(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	type countRow struct {
+		TagID uint
+		Cnt   int64
+	}
+	var rows []countRow
+	store.DB().Model(&models.MembershipTag{}).Select("tag_id, COUNT(*) AS cnt").Where("club_id = ?", clubID).Group("tag_id").Scan(&rows)
+	counts := make(map[uint]int64, len(rows))
+	for _, r := range rows {
+		counts[r.TagID] = r.Cnt
+	}
+	items := make([]MemberTagItem, 0, len(tags))
+	for _, t := range tags {
+		items = append(items, MemberTagItem{MemberTag: t, UsageCount: counts[t.ID]})
+	}
+	c.JSON(http.StatusOK, response.Success(items))
+}
+
+// @Summary 创建成员标签（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body MemberTagReq true "标签"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/tags [post]
+func CreateMemberTag(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req MemberTagReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	var cnt int64
+	store.DB().Model(&models.MemberTag{}).Where("club_id = ? AND name = ?", clubID, name).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "标签已存在"))
+		return
+	}
+	t := models.MemberTag{ClubID: uint(clubID), Name: name, Color: req.Color}
+	if err := store.DB().Create(&t).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(t))
+}
+
+// @Summary 更新成员标签（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "标签ID"
+// @Param payload body MemberTagReq true "标签"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/tags/{id} [put]
+func UpdateMemberTag(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req MemberTagReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var t models.MemberTag
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&t).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "标签不存在"))
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	var cnt int64
+	store.DB().Model(&models.MemberTag{}).Where("club_id = ? AND name = ? AND id <> ?", clubID, name, t.ID).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "标签已存在"))
+		return
+	}
+	if err := store.DB().Model(&t).Updates(map[string]any{"name": name, "color": req.Color}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(t))
+}
+
+// @Summary 删除成员标签（负责人）
+// @Description 同时移除所有成员上的该标签
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "标签ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/tags/{id} [delete]
+func DeleteMemberTag(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	err := store.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ? AND club_id = ?", id, clubID).Delete(&models.MembershipTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND club_id = ?", id, clubID).Delete(&models.MemberTag{}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 设置成员标签（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Param payload body SetMemberTagsReq true "标签ID列表"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/{id}/tags [put]
+func SetMemberTags(c *gin.Context) {
+	_, m, ok := leaderMembership(c)
+	if !ok {
+		return
+	}
+	var req SetMemberTagsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var tags []models.MemberTag
+	if len(req.TagIDs) > 0 {
+		store.DB().Where("id IN ? AND club_id = ?", req.TagIDs, m.ClubID).Find(&tags)
+		if len(tags) != len(uniqueUints(req.TagIDs)) {
+			c.JSON(http.StatusBadRequest, response.Error(400, "标签不存在"))
+			return
+		}
+	}
+	err := store.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("membership_id = ?", m.ID).Delete(&models.MembershipTag{}).Error; err != nil {
+			return err
+		}
+		for _, t := range tags {
+			if err := tx.Create(&models.MembershipTag{MembershipID: m.ID, TagID: t.ID, ClubID: m.ClubID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(tags))
+}
+
+// @Summary 成员备注列表（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/{id}/notes [get]
+func ListMemberNotes(c *gin.Context) {
+	_, m, ok := leaderMembership(c)
+	if !ok {
+		return
+	}
+	var list []models.MemberNote
+	if err := store.DB().Where("membership_id = ?", m.ID).Order("id DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 添加成员备注（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Param payload body MemberNoteReq true "备注内容"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/{id}/notes [post]
+func CreateMemberNote(c *gin.Context) {
+	u, m, ok := leaderMembership(c)
+	if !ok {
+		return
+	}
+	var req MemberNoteReq
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Content) == "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	n := models.MemberNote{MembershipID: m.ID, ClubID: m.ClubID, AuthorID: u.ID, AuthorName: u.Name, Content: strings.TrimSpace(req.Content)}
+	if err := store.DB().Create(&n).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(n))
+}
+
+// @Summary 删除成员备注（负责人，仅作者或社长）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Param noteId path int true "备注ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/{id}/notes/{noteId} [delete]
+func DeleteMemberNote(c *gin.Context) {
+	u, m, ok := leaderMembership(c)
+	if !ok {
+		return
+	}
+	noteID, err := strconv.Atoi(c.Param("noteId"))
+	if err != nil || noteID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var n models.MemberNote
+	if err := store.DB().Where("id = ? AND membership_id = ?", noteID, m.ID).First(&n).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "备注不存在"))
+		return
+	}
+	if n.AuthorID != u.ID && !authz.IsAdmin(u) {
+		var me models.Membership
+		if store.DB().Where("user_id = ? AND club_id = ?", u.ID, m.ClubID).First(&me).Error != nil || me.Role != "leader" {
+			c.JSON(http.StatusForbidden, response.Error(403, "只能删除自己的备注"))
+			return
+		}
+	}
+	if err := store.DB().Delete(&n).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "成员备注", fmt.Sprintf("删除成员 %d 的备注 %d", m.UserID, n.ID), m.ClubID)
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+func uniqueUints(list []uint) []uint {
+	seen := make(map[uint]bool, len(list))
+	out := make([]uint, 0, len(list))
+	for _, v := range list {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
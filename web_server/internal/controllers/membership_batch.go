@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/membership"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 单次批量审批的最大数量
+const maxBatchReview = 500
+
+type RejectMembershipReq struct {
+	Reason string `json:"reason"` // 驳回原因，申请人可见
+}
+
+// MembershipReasonReq 审批通过、移出、退出等操作的可选原因，记入成员变更记录
+type MembershipReasonReq struct {
+	Reason string `json:"reason"`
+}
+
+// bindReason 读取可选的原因，超长时返回 400 并返回 false
+func bindReason(c *gin.Context) (string, bool) {
+	var req MembershipReasonReq
+	_ = c.ShouldBindJSON(&req) // 请求体可选
+	if len([]rune(req.Reason)) > 255 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "原因过长"))
+		return "", false
+	}
+	return req.Reason, true
+}
+
+type BatchReviewReq struct {
+	IDs    []uint `json:"ids" binding:"required"`
+	Reason string `json:"reason"` // 驳回时申请人可见，通过时记入成员变更记录
+}
+
+type BatchReviewResult struct {
+	ID      uint   `json:"id"`
+	Status  string `json:"status"` // approved, rejected, skipped
+	Message string `json:"message,omitempty"`
+}
+
+// approveApplication 审批通过入社申请：检查招新名额并设置到期时间，reason 记入成员变更记录
+// 非待审核或名额不足时返回提示信息且不修改记录，已驳回、退出或移出的成员须重新申请
+func approveApplication(db *gorm.DB, m *models.Membership, reason string, actorID uint, now time.Time) (string, error) {
+	if m.Status != "pending" {
+		return "非待审核状态", nil
+	}
+	if m.CampaignID != nil {
+		var camp models.RecruitmentCampaign
+		var applicant models.User
+		if db.Where("id = ?", *m.CampaignID).First(&camp).Error == nil && db.Where("id = ?", m.UserID).First(&applicant).Error == nil {
+			if msg := campaignQuotaError(db, &camp, applicant.College, m.ID); msg != "" {
+				return msg, nil
+			}
+		}
+	}
+	var cl models.Club
+	if err := db.Where("id = ?", m.ClubID).First(&cl).Error; err == nil && !membership.IsLeaderRole(m.Role) {
+		m.ExpiresAt = membership.ExpiryFrom(cl, now)
+	}
+	if err := membership.TransitWithReason(db, m, "approved", actorID, now, reason); err != nil {
+		if membership.IsStateError(err) {
+			return err.Error(), nil
+		}
+		return "", err
+	}
+	return "", nil
+}
+
+// rejectApplication 驳回入社申请
+func rejectApplication(db *gorm.DB, m *models.Membership, reason string, actorID uint, now time.Time) error {
+	if m.Status != "pending" {
+		return &membership.StateError{Msg: "非待审核状态"}
+	}
+	m.RejectReason = reason
+	return membership.Transit(db, m, "rejected", actorID, now)
+}
+
+func rejectLogContent(userID uint, reason string) string {
+	if reason == "" {
+		return fmt.Sprintf("驳回成员 %d 加入社团", userID)
+	}
+	return fmt.Sprintf("驳回成员 %d 加入社团，原因：%s", userID, reason)
+}
+
+// @Summary 批量审批通过（负责人）
+// @Description 在同一事务中处理，逐条返回结果；非待审核或名额已满的申请跳过
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body BatchReviewReq true "成员关系ID列表"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/batch-approve [post]
+func BatchApproveMemberships(c *gin.Context) {
+	batchReviewMemberships(c, true)
+}
+
+// @Summary 批量驳回（负责人）
+// @Description 在同一事务中处理，逐条返回结果；驳回原因对申请人可见
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body BatchReviewReq true "成员关系ID列表与驳回原因"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/batch-reject [post]
+func BatchRejectMemberships(c *gin.Context) {
+	batchReviewMemberships(c, false)
+}
+
+func batchReviewMemberships(c *gin.Context, approve bool) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req BatchReviewReq
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if len(req.IDs) > maxBatchReview {
+		c.JSON(http.StatusBadRequest, response.Error(400, fmt.Sprintf("单次最多处理 %d 条", maxBatchReview)))
+		return
+	}
+	if len([]rune(req.Reason)) > 255 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "原因过长"))
+		return
+	}
+	now := time.Now()
+	results := make([]BatchReviewResult, 0, len(req.IDs))
+	var logs []string
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		seen := make(map[uint]bool, len(req.IDs))
+		for _, id := range req.IDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			var m models.Membership
+			if err := tx.Where("id = ? AND club_id = ?", id, clubID).First(&m).Error; err != nil {
+				results = append(results, BatchReviewResult{ID: id, Status: "skipped", Message: "成员不存在"})
+				continue
+			}
+			if approve {
+				msg, err := approveApplication(tx, &m, req.Reason, u.ID, now)
+				if err != nil {
+					return err
+				}
+				if msg != "" {
+					results = append(results, BatchReviewResult{ID: id, Status: "skipped", Message: msg})
+					continue
+				}
+				results = append(results, BatchReviewResult{ID: id, Status: "approved"})
+				logs = append(logs, fmt.Sprintf("批准成员 %d 加入社团（批量）", m.UserID))
+			} else {
+				if err := rejectApplication(tx, &m, req.Reason, u.ID, now); err != nil {
+					if membership.IsStateError(err) {
+						results = append(results, BatchReviewResult{ID: id, Status: "skipped", Message: err.Error()})
+						continue
+					}
+					return err
+				}
+				results = append(results, BatchReviewResult{ID: id, Status: "rejected"})
+				logs = append(logs, rejectLogContent(m.UserID, req.Reason)+"（批量）")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "批量审批失败，已全部回滚"))
+		return
+	}
+	// 事务提交后再写日志，回滚的操作不留记录
+	for _, content := range logs {
+		RecordLog(u.ID, u.Name, "审批申请", content, uint(clubID))
+	}
+	c.JSON(http.StatusOK, response.Success(results))
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type EventActor struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type MembershipEventItem struct {
+	models.MembershipEvent
+	Actor *EventActor `json:"actor"` // 系统任务产生的记录为 null
+}
+
+// membershipEvents 按时间顺序分页返回某条成员关系的变更记录，操作人只返回 ID 与姓名
+func membershipEvents(c *gin.Context, membershipID uint) {
+	q := store.DB().Model(&models.MembershipEvent{}).Where("membership_id = ?", membershipID).
+		Preload("Actor", func(db *gorm.DB) *gorm.DB { return db.Select("id", "name") }).Order("id ASC")
+	var list []models.MembershipEvent
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q, pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	items := make([]MembershipEventItem, 0, len(list))
+	for _, e := range list {
+		item := MembershipEventItem{MembershipEvent: e}
+		if e.ActorID != 0 {
+			item.Actor = &EventActor{ID: e.ActorID, Name: e.Actor.Name}
+		}
+		items = append(items, item)
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": items, "pagination": info}))
+}
+
+// @Summary 成员变更记录（负责人）
+// @Description 按时间顺序列出成员的申请、审批、角色调整、退社等记录，含操作人与原因
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/memberships/{id}/events [get]
+func ListMemberEvents(c *gin.Context) {
+	clubID, err1 := strconv.Atoi(c.Param("clubId"))
+	id, err2 := strconv.Atoi(c.Param("id"))
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var m models.Membership
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
+		return
+	}
+	membershipEvents(c, m.ID)
+}
+
+// @Summary 我在社团的变更记录
+// @Tags 学生
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/clubs/{clubId}/events [get]
+func MyMembershipEvents(c *gin.Context) {
+	clubID, err := strconv.Atoi(c.Param("clubId"))
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var m models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ?", u.ID, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "未加入该社团"))
+		return
+	}
+	membershipEvents(c, m.ID)
+}
@@ -50,6 +50,7 @@ func ListPublicAnnouncements(c *gin.Context) {
 // @Param keyword query string false "关键词"
 // @Param start query string false "开始时间"
 // @Param end query string false "结束时间"
+// @Param termId query string false "学期ID，current 表示当前学期，默认不限学期"
 // @Param page query int false "页码"
 // @Param pageSize query int false "每页数量"
 // @Success 200 {object} response.Body
@@ -71,6 +72,19 @@ func ListPublicActivities(c *gin.Context) {
 	if end := strings.TrimSpace(c.Query("end")); end != "" {
 		q = q.Where("(end_at IS NOT NULL AND end_at <= ?) OR (end_at IS NULL AND time <= ?)", end, end)
 	}
+	// 公开列表默认不限学期，以免隐藏下学期的活动或与 start/end 取交集
+	var term *models.Term
+	if c.Query("termId") != "" {
+		t, ok := termFilter(c, "termId")
+		if !ok {
+			return
+		}
+		term = t
+	}
+	if term != nil {
+		start, end := termBounds(term)
+		q = q.Where("(start_at IS NOT NULL AND start_at BETWEEN ? AND ?) OR (start_at IS NULL AND time BETWEEN ? AND ?)", start, end, start, end)
+	}
 	var list []models.Activity
 	pg := pagination.Get(c)
 	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
@@ -121,7 +135,7 @@ func GetClubDetailPublic(c *gin.Context) {
 		return
 	}
 	var leaders []models.Membership
-	_ = store.DB().Where("club_id = ? AND role IN ?", club.ID, []string{"leader", "advisor"}).Preload("User").Find(&leaders)
+	_ = store.DB().Where("club_id = ? AND role IN ? AND status = ?", club.ID, []string{"leader", "advisor"}, "approved").Preload("User").Find(&leaders)
 	var acts []models.Activity
 	_ = store.DB().Where("club_id = ? AND scope = ?", club.ID, "public").Order("id DESC").Limit(5).Find(&acts)
 	// 统计成员数量（当前与历史）
@@ -131,7 +145,7 @@ func GetClubDetailPublic(c *gin.Context) {
 		Count(&currentCount).Error
 	var historyCount int64
 	_ = store.DB().Model(&models.Membership{}).
-		Where("club_id = ? AND status IN ?", club.ID, []string{"approved", "quit"}).
+		Where("club_id = ? AND status IN ?", club.ID, []string{"approved", "quit", "expired"}).
 		Distinct("user_id").
 		Count(&historyCount).Error
 	res := map[string]any{
@@ -0,0 +1,420 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CampaignReq struct {
+	Title         string         `json:"title" binding:"required"`
+	StartAt       string         `json:"start_at" binding:"required"` // YYYY-MM-DD HH:MM:SS
+	EndAt         string         `json:"end_at" binding:"required"`   // YYYY-MM-DD HH:MM:SS
+	Quota         int            `json:"quota"`                       // 0 表示不限
+	CollegeQuotas map[string]int `json:"college_quotas"`
+}
+
+type RecruitmentSettingsReq struct {
+	RequireCampaign bool `json:"require_campaign"`
+}
+
+type CollegeCount struct {
+	College  string `json:"college"`
+	Quota    int    `json:"quota"`
+	Applied  int64  `json:"applied"`
+	Approved int64  `json:"approved"`
+}
+
+type CampaignStats struct {
+	models.RecruitmentCampaign
+	Applied   int64          `json:"applied"` // 待审核 + 已通过
+	Pending   int64          `json:"pending"`
+	Approved  int64          `json:"approved"`
+	Rejected  int64          `json:"rejected"`
+	Remaining int            `json:"remaining"` // 剩余名额，不限名额时为 -1
+	ByCollege []CollegeCount `json:"by_college"`
+}
+
+// activeCampaign 返回社团当前进行中的招新活动
+func activeCampaign(db *gorm.DB, clubID uint, now time.Time) *models.RecruitmentCampaign {
+	var camp models.RecruitmentCampaign
+	if err := db.Where("club_id = ? AND start_at <= ? AND end_at >= ?", clubID, now, now).First(&camp).Error; err != nil {
+		return nil
+	}
+	return &camp
+}
+
+// campaignQuotaError 检查招新名额，college 为申请人所在学院，excludeID 为正在审批的成员关系
+// 名额按已通过人数计算，名额已满时返回提示
+func campaignQuotaError(db *gorm.DB, camp *models.RecruitmentCampaign, college string, excludeID uint) string {
+	if camp.Quota > 0 {
+		var cnt int64
+		db.Model(&models.Membership{}).Where("campaign_id = ? AND status = ? AND id <> ?", camp.ID, "approved", excludeID).Count(&cnt)
+		if cnt >= int64(camp.Quota) {
+			return "招新名额已满"
+		}
+	}
+	if q, ok := camp.CollegeQuotas[college]; ok && college != "" {
+		var cnt int64
+		db.Model(&models.Membership{}).
+			Joins("JOIN users ON users.id = memberships.user_id").
+			Where("memberships.campaign_id = ? AND memberships.status = ? AND memberships.id <> ? AND users.college = ?", camp.ID, "approved", excludeID, college).
+			Count(&cnt)
+		if cnt >= int64(q) {
+			return "本学院招新名额已满"
+		}
+	}
+	return ""
+}
+
+func parseCampaignReq(req CampaignReq, clubID uint) (models.RecruitmentCampaign, string) {
+	start, err1 := time.ParseInLocation("2006-01-02 15:04:05", req.StartAt, time.Local)
+	end, err2 := time.ParseInLocation("2006-01-02 15:04:05", req.EndAt, time.Local)
+	if err1 != nil || err2 != nil {
+		return models.RecruitmentCampaign{}, "时间格式应为 YYYY-MM-DD HH:MM:SS"
+	}
+	if !end.After(start) {
+		return models.RecruitmentCampaign{}, "结束时间需晚于开始时间"
+	}
+	if req.Quota < 0 {
+		return models.RecruitmentCampaign{}, "名额不能为负数"
+	}
+	quotas := make(map[string]int, len(req.CollegeQuotas))
+	for college, q := range req.CollegeQuotas {
+		college = strings.TrimSpace(college)
+		if college == "" || q < 0 {
+			return models.RecruitmentCampaign{}, "学院名额设置无效"
+		}
+		quotas[college] = q
+	}
+	return models.RecruitmentCampaign{ClubID: clubID, Title: req.Title, StartAt: start, EndAt: end, Quota: req.Quota, CollegeQuotas: quotas}, ""
+}
+
+// campaignOverlaps 检查同一社团的招新时间段是否重叠
+func campaignOverlaps(camp models.RecruitmentCampaign, excludeID uint) bool {
+	var cnt int64
+	store.DB().Model(&models.RecruitmentCampaign{}).
+		Where("club_id = ? AND id <> ? AND start_at <= ? AND end_at >= ?", camp.ClubID, excludeID, camp.EndAt, camp.StartAt).
+		Count(&cnt)
+	return cnt > 0
+}
+
+func campaignStats(camp models.RecruitmentCampaign) CampaignStats {
+	return campaignStatsFor([]models.RecruitmentCampaign{camp})[0]
+}
+
+// campaignStatsFor 用一次分组查询统计多个招新活动的申请与名额，结果顺序与 camps 一致
+func campaignStatsFor(camps []models.RecruitmentCampaign) []CampaignStats {
+	out := make([]CampaignStats, len(camps))
+	if len(camps) == 0 {
+		return out
+	}
+	ids := make([]uint, 0, len(camps))
+	for _, camp := range camps {
+		ids = append(ids, camp.ID)
+	}
+	var rows []struct {
+		CampaignID uint
+		Status     string
+		College    string
+		Cnt        int64
+	}
+	store.DB().Table("memberships").
+		Select("memberships.campaign_id, memberships.status, users.college, COUNT(*) AS cnt").
+		Joins("JOIN users ON users.id = memberships.user_id").
+		Where("memberships.campaign_id IN ?", ids).
+		Group("memberships.campaign_id, memberships.status, users.college").
+		Scan(&rows)
+	byCampaign := make(map[uint][]int, len(rows))
+	for i, r := range rows {
+		byCampaign[r.CampaignID] = append(byCampaign[r.CampaignID], i)
+	}
+	for i, camp := range camps {
+		st := CampaignStats{RecruitmentCampaign: camp, Remaining: -1}
+		byCollege := map[string]*CollegeCount{}
+		for college, q := range camp.CollegeQuotas {
+			byCollege[college] = &CollegeCount{College: college, Quota: q}
+		}
+		for _, j := range byCampaign[camp.ID] {
+			r := rows[j]
+			switch r.Status {
+			case "pending":
+				st.Pending += r.Cnt
+			case "approved":
+				st.Approved += r.Cnt
+			case "rejected":
+				st.Rejected += r.Cnt
+			}
+			cc, ok := byCollege[r.College]
+			if !ok {
+				cc = &CollegeCount{College: r.College}
+				byCollege[r.College] = cc
+			}
+			if r.Status == "pending" || r.Status == "approved" {
+				cc.Applied += r.Cnt
+			}
+			if r.Status == "approved" {
+				cc.Approved += r.Cnt
+			}
+		}
+		st.Applied = st.Pending + st.Approved
+		if camp.Quota > 0 {
+			st.Remaining = camp.Quota - int(st.Approved)
+			if st.Remaining < 0 {
+				st.Remaining = 0
+			}
+		}
+		st.ByCollege = make([]CollegeCount, 0, len(byCollege))
+		for _, cc := range byCollege {
+			st.ByCollege = append(st.ByCollege, *cc)
+		}
+		out[i] = st
+	}
+	return out
+}
+
+// @Summary 正在招新的社团（公开）
+// @Tags 公共
+// @Produce json
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Success 200 {object} response.Body
+// @Router /public/recruiting [get]
+func ListRecruitingClubs(c *gin.Context) {
+	now := time.Now()
+	q := store.DB().Model(&models.RecruitmentCampaign{}).
+		Joins("JOIN clubs ON clubs.id = recruitment_campaigns.club_id").
+		Where("clubs.status = ? AND recruitment_campaigns.start_at <= ? AND recruitment_campaigns.end_at >= ?", "approved", now, now).
+		Preload("Club").Preload("Club.Category").Order("recruitment_campaigns.end_at ASC")
+	var list []models.RecruitmentCampaign
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q, pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	stats := campaignStatsFor(list)
+	items := make([]map[string]any, 0, len(list))
+	for i, camp := range list {
+		st := stats[i]
+		items = append(items, map[string]any{
+			"campaign_id": camp.ID,
+			"title":       camp.Title,
+			"start_at":    camp.StartAt,
+			"end_at":      camp.EndAt,
+			"quota":       camp.Quota,
+			"remaining":   st.Remaining,
+			"club":        camp.Club,
+		})
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": items, "pagination": info}))
+}
+
+// @Summary 招新活动列表（负责人，含实时申请与名额统计）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/campaigns [get]
+func ListCampaigns(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var list []models.RecruitmentCampaign
+	if err := store.DB().Where("club_id = ?", clubID).Order("start_at DESC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(campaignStatsFor(list)))
+}
+
+// @Summary 创建招新活动（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body CampaignReq true "招新活动"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/campaigns [post]
+func CreateCampaign(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req CampaignReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	camp, msg := parseCampaignReq(req, uint(clubID))
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	if campaignOverlaps(camp, 0) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "招新时间与已有招新活动重叠"))
+		return
+	}
+	camp.CreatedBy = u.ID
+	if err := store.DB().Create(&camp).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("创建招新活动: %s", camp.Title), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(camp))
+}
+
+// @Summary 修改招新活动（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "招新活动ID"
+// @Param payload body CampaignReq true "招新活动"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/campaigns/{id} [put]
+func UpdateCampaign(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req CampaignReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var camp models.RecruitmentCampaign
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&camp).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "招新活动不存在"))
+		return
+	}
+	nc, msg := parseCampaignReq(req, uint(clubID))
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	if campaignOverlaps(nc, camp.ID) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "招新时间与已有招新活动重叠"))
+		return
+	}
+	camp.Title, camp.StartAt, camp.EndAt, camp.Quota, camp.CollegeQuotas = nc.Title, nc.StartAt, nc.EndAt, nc.Quota, nc.CollegeQuotas
+	if err := store.DB().Save(&camp).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("修改招新活动 %d: %s", camp.ID, camp.Title), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(camp))
+}
+
+// @Summary 删除招新活动（负责人，仅限尚无申请的活动）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "招新活动ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/campaigns/{id} [delete]
+func DeleteCampaign(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var cnt int64
+	store.DB().Model(&models.Membership{}).Where("campaign_id = ?", id).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "已有申请，不能删除"))
+		return
+	}
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).Delete(&models.RecruitmentCampaign{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("删除招新活动 %d", id), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 设置招新规则（负责人）
+// @Description require_campaign 为 true 时，招新活动以外的时间不接受入社申请
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body RecruitmentSettingsReq true "招新规则"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/recruitment-settings [put]
+func UpdateRecruitmentSettings(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageRecruitment) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req RecruitmentSettingsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if err := store.DB().Model(&models.Club{}).Where("id = ?", clubID).Update("require_campaign", req.RequireCampaign).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "招新管理", fmt.Sprintf("设置仅招新期接受申请: %v", req.RequireCampaign), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(req))
+}
@@ -0,0 +1,299 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/membership"
+	"web_server/internal/store"
+	"web_server/pkg/pagination"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type MembershipSettingsReq struct {
+	MembershipMonths        int  `json:"membership_months"`   // 0 表示长期有效
+	RenewalWindowDays       int  `json:"renewal_window_days"` // 到期前多少天内可续期
+	RenewalRequiresApproval bool `json:"renewal_requires_approval"`
+}
+
+// @Summary 续期社团成员资格
+// @Description 到期前的续期窗口内或已到期后可申请；社团要求审核时进入待审核
+// @Tags 学生
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/clubs/{clubId}/renew [post]
+func RenewMembership(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var cl models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	var m models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ?", u.ID, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "非社团成员"))
+		return
+	}
+	now := time.Now()
+	if err := membership.CheckRenewable(cl, m, now); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+		return
+	}
+	if cl.RenewalRequiresApproval {
+		m.RenewalStatus, m.RenewalRequestedAt = "pending", &now
+		if err := store.DB().Model(&m).Updates(map[string]any{"renewal_status": "pending", "renewal_requested_at": now}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(500, "申请失败"))
+			return
+		}
+		c.JSON(http.StatusOK, response.Success(m))
+		return
+	}
+	if err := membership.Renew(store.DB(), cl, &m, u.ID, now); err != nil {
+		if membership.IsStateError(err) {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.Error(500, "续期失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(m))
+}
+
+// @Summary 待审核续期申请（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/renewals [get]
+func ListPendingRenewals(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	q := store.DB().Model(&models.Membership{}).Where("club_id = ? AND renewal_status = ?", clubID, "pending").Preload("User").Order("renewal_requested_at ASC")
+	var list []models.Membership
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q, pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 批准续期（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/renewals/{id}/approve [post]
+func ApproveRenewal(c *gin.Context) {
+	reviewRenewal(c, true)
+}
+
+// @Summary 驳回续期（负责人）
+// @Tags 成员
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "成员关系ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/renewals/{id}/reject [post]
+func RejectRenewal(c *gin.Context) {
+	reviewRenewal(c, false)
+}
+
+func reviewRenewal(c *gin.Context, approve bool) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var m models.Membership
+	if err := store.DB().Preload("Club").Preload("User").Where("id = ? AND club_id = ?", id, clubID).First(&m).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "成员不存在"))
+		return
+	}
+	if m.RenewalStatus != "pending" {
+		c.JSON(http.StatusBadRequest, response.Error(400, "没有待审核的续期申请"))
+		return
+	}
+	if approve {
+		if err := membership.Renew(store.DB(), m.Club, &m, u.ID, time.Now()); err != nil {
+			if membership.IsStateError(err) {
+				c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+			return
+		}
+		RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("批准 %s 续期", m.User.Name), uint(clubID))
+	} else {
+		m.RenewalStatus, m.RenewalRequestedAt = "", nil
+		if err := store.DB().Model(&m).Updates(map[string]any{"renewal_status": "", "renewal_requested_at": nil}).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+			return
+		}
+		RecordLog(u.ID, u.Name, "审批申请", fmt.Sprintf("驳回 %s 续期", m.User.Name), uint(clubID))
+	}
+	c.JSON(http.StatusOK, response.Success(m))
+}
+
+// @Summary 设置成员有效期与续期规则（负责人）
+// @Tags 成员
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body MembershipSettingsReq true "有效期设置"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/membership-settings [put]
+func UpdateMembershipSettings(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageClub) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req MembershipSettingsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.MembershipMonths < 0 || req.MembershipMonths > 60 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "有效期范围为 0-60 个月"))
+		return
+	}
+	if req.RenewalWindowDays < 0 || req.RenewalWindowDays > 365 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "续期窗口范围为 0-365 天"))
+		return
+	}
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Club{}).Where("id = ?", clubID).Updates(map[string]any{
+			"membership_months":         req.MembershipMonths,
+			"renewal_window_days":       req.RenewalWindowDays,
+			"renewal_requires_approval": req.RenewalRequiresApproval,
+		}).Error; err != nil {
+			return err
+		}
+		if req.MembershipMonths != 0 {
+			return nil
+		}
+		// 改为长期有效时清除现有到期时间，已到期的成员恢复为正式成员
+		if err := tx.Model(&models.Membership{}).Where("club_id = ?", clubID).
+			Updates(map[string]any{"expires_at": nil, "renewal_status": "", "renewal_requested_at": nil}).Error; err != nil {
+			return err
+		}
+		var expired []models.Membership
+		if err := tx.Where("club_id = ? AND status = ?", clubID, "expired").Find(&expired).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		for i := range expired {
+			m := &expired[i]
+			m.ExpiresAt, m.RenewalStatus, m.RenewalRequestedAt = nil, "", nil
+			if err := membership.TransitWithReason(tx, m, "approved", u.ID, now, "社团改为长期有效"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改权限", fmt.Sprintf("设置成员有效期 %d 个月，续期窗口 %d 天，续期审核 %v", req.MembershipMonths, req.RenewalWindowDays, req.RenewalRequiresApproval), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(req))
+}
+
+// @Summary 我的通知
+// @Tags 学生
+// @Produce json
+// @Param unread query bool false "仅未读"
+// @Param page query int false "页码"
+// @Param pageSize query int false "每页数量"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/notifications [get]
+func MyNotifications(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	q := store.DB().Model(&models.Notification{}).Where("user_id = ?", u.ID)
+	if c.Query("unread") == "true" {
+		q = q.Where("read_at IS NULL")
+	}
+	var list []models.Notification
+	pg := pagination.Get(c)
+	info, err := pagination.Do(q.Order("id DESC"), pg, &list)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(map[string]any{"list": list, "pagination": info}))
+}
+
+// @Summary 标记通知已读
+// @Tags 学生
+// @Produce json
+// @Param id path int true "通知ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /student/notifications/{id}/read [post]
+func ReadNotification(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if err := store.DB().Model(&models.Notification{}).Where("id = ? AND user_id = ? AND read_at IS NULL", id, u.ID).Update("read_at", time.Now()).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "操作失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(nil))
+}
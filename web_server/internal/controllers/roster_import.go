@@ -0,0 +1,365 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/membership"
+	"web_server/internal/roster"
+	"web_server/internal/store"
+	"web_server/pkg/password"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 名册文件大小上限
+const maxRosterFileSize = 2 << 20
+
+// errRosterDryRun 预览模式下用于回滚事务
+var errRosterDryRun = errors.New("dry run")
+
+type RosterImportRow struct {
+	roster.Row
+	Status       string `json:"status"` // joined, created, skipped, error
+	Message      string `json:"message,omitempty"`
+	UserID       uint   `json:"user_id,omitempty"`
+	TempPassword string `json:"temp_password,omitempty"` // 仅正式导入且自动生成密码时返回
+}
+
+type RosterImportResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Joined  int               `json:"joined"`
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Rows    []RosterImportRow `json:"rows"`
+}
+
+// @Summary 导入成员名册（负责人）
+// @Description 支持 CSV/XLSX，表头需包含“学号”，可选“姓名、学院、手机、角色”；按学号匹配用户并直接加入社团，
+// @Description 填写了姓名但与该学号账号的姓名不一致的行报错不导入；“角色”不能是社长，也不能拥有导入者没有的权限。
+// @Description dry_run=true 时仅预览不落库；create_accounts=true 时为未注册的学号创建账号（账号即学号），
+// @Description 未提供 temp_password 时为每个新账号随机生成临时密码并在结果中返回。
+// @Tags 成员
+// @Accept multipart/form-data
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param file formData file true "名册文件"
+// @Param dry_run formData bool false "仅预览"
+// @Param create_accounts formData bool false "为未注册学号创建账号"
+// @Param temp_password formData string false "新账号统一临时密码"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/roster/import [post]
+func ImportRoster(c *gin.Context) {
+	clubID, err := strconv.Atoi(c.Param("clubId"))
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermApproveMembers) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var cl models.Club
+	if err := store.DB().Where("id = ?", clubID).First(&cl).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "社团不存在"))
+		return
+	}
+	dryRun := c.PostForm("dry_run") == "true"
+	createAccounts := c.PostForm("create_accounts") == "true"
+	tempPassword := c.PostForm("temp_password")
+	if tempPassword != "" && (len(tempPassword) < 6 || len(tempPassword) > 64) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "临时密码长度需为 6-64 位"))
+		return
+	}
+	f, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "缺少文件"))
+		return
+	}
+	if f.Size > maxRosterFileSize {
+		c.JSON(http.StatusBadRequest, response.Error(400, "文件不能超过 2MB"))
+		return
+	}
+	src, err := f.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "读取文件失败"))
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(src, maxRosterFileSize+1))
+	src.Close()
+	if err != nil || len(data) > maxRosterFileSize {
+		c.JSON(http.StatusBadRequest, response.Error(400, "读取文件失败"))
+		return
+	}
+	rows, err := roster.Parse(f.Filename, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+		return
+	}
+	if len(rows) == 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "名册为空"))
+		return
+	}
+	roles := rosterRoleLookup(uint(clubID))
+	grantable, err := rosterGrantableRoles(u, uint(clubID))
+	if err != nil {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	sharedHash := ""
+	if createAccounts && tempPassword != "" && !dryRun {
+		if sharedHash, err = password.Hash(tempPassword); err != nil {
+			c.JSON(http.StatusInternalServerError, response.Error(500, "导入失败"))
+			return
+		}
+	}
+	// 逐个生成的临时密码在开启事务前完成哈希，避免 bcrypt 计算期间长时间持有锁
+	generated, err := rosterGeneratedPasswords(rows, createAccounts && !dryRun && tempPassword == "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "导入失败"))
+		return
+	}
+	var studentRole models.Role
+	if createAccounts {
+		if err := store.DB().Where("code = ?", "student").First(&studentRole).Error; err != nil {
+			studentRole = models.Role{Name: "学生", Code: "student"}
+			_ = store.DB().Create(&studentRole).Error
+		}
+	}
+
+	now := time.Now()
+	result := RosterImportResult{DryRun: dryRun, Total: len(rows), Rows: make([]RosterImportRow, 0, len(rows))}
+	err = store.DB().Transaction(func(tx *gorm.DB) error {
+		seen := make(map[string]int, len(rows))
+		for _, r := range rows {
+			item := RosterImportRow{Row: r}
+			fail := func(msg string) {
+				item.Status, item.Message = "error", msg
+				result.Failed++
+				result.Rows = append(result.Rows, item)
+			}
+			if r.StudentNo == "" {
+				fail("学号为空")
+				continue
+			}
+			if len(r.StudentNo) > 32 {
+				fail("学号过长")
+				continue
+			}
+			if line, dup := seen[r.StudentNo]; dup {
+				fail(fmt.Sprintf("与第 %d 行学号重复", line))
+				continue
+			}
+			seen[r.StudentNo] = r.Line
+			roleCode := "member"
+			if r.Role != "" {
+				code, ok := roles[strings.ToLower(r.Role)]
+				if !ok {
+					fail("角色不存在：" + r.Role)
+					continue
+				}
+				roleCode = code
+			}
+			if roleCode == "leader" {
+				fail("不能通过导入设置社长，请使用社长交接")
+				continue
+			}
+			if !grantable[roleCode] {
+				fail("权限不足：不能导入权限超出自己的角色")
+				continue
+			}
+
+			var users []models.User
+			if err := tx.Where("student_no = ?", r.StudentNo).Limit(2).Find(&users).Error; err != nil {
+				return err
+			}
+			var target models.User
+			switch {
+			case len(users) > 1:
+				fail("该学号对应多个账号，请手动处理")
+				continue
+			case len(users) == 1:
+				target = users[0]
+				// 学号填错时可能匹配到其他学生，姓名对不上的行不导入
+				if !rosterNameMatches(r.Name, target.Name) {
+					fail("姓名与该学号的账号不一致，请核对学号")
+					continue
+				}
+			case !createAccounts:
+				fail("未找到该学号对应的账号")
+				continue
+			default:
+				var n int64
+				if err := tx.Model(&models.User{}).Where("account = ?", r.StudentNo).Count(&n).Error; err != nil {
+					return err
+				}
+				if n > 0 {
+					fail("账号已被占用，无法以学号创建账号")
+					continue
+				}
+				if len([]rune(r.Name)) > 64 || len([]rune(r.College)) > 64 || len(r.Phone) > 20 {
+					fail("姓名、学院或手机过长")
+					continue
+				}
+				hash := sharedHash
+				if dryRun {
+					hash = "-" // 预览时不计算哈希，事务最终会回滚
+				} else if hash == "" {
+					g, ok := generated[r.StudentNo]
+					if !ok {
+						fail("账号状态已变化，请重新导入")
+						continue
+					}
+					hash, item.TempPassword = g.hash, g.password
+				}
+				target = models.User{Account: r.StudentNo, Password: hash, Name: r.Name, College: r.College, StudentNo: r.StudentNo, Phone: r.Phone, RoleID: studentRole.ID}
+				if err := tx.Create(&target).Error; err != nil {
+					return err
+				}
+				item.Status = "created"
+			}
+			item.UserID = target.ID
+
+			var m models.Membership
+			err := tx.Where("user_id = ? AND club_id = ?", target.ID, clubID).First(&m).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if m.ID != 0 && m.Status == "approved" {
+				item.Status, item.Message = "skipped", "已是社团成员"
+				result.Skipped++
+				result.Rows = append(result.Rows, item)
+				continue
+			}
+			m.UserID, m.ClubID, m.Role = target.ID, uint(clubID), roleCode
+			m.ExpiresAt = nil
+			if !membership.IsLeaderRole(roleCode) {
+				m.ExpiresAt = membership.ExpiryFrom(cl, now)
+			}
+			if err := membership.TransitWithReason(tx, &m, "approved", u.ID, now, "名册导入："+f.Filename); err != nil {
+				if membership.IsStateError(err) {
+					fail(err.Error())
+					continue
+				}
+				return err
+			}
+			if item.Status == "created" {
+				result.Created++
+			} else {
+				item.Status = "joined"
+			}
+			result.Joined++
+			result.Rows = append(result.Rows, item)
+		}
+		if dryRun {
+			return errRosterDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRosterDryRun) {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "导入失败，已全部回滚"))
+		return
+	}
+	if !dryRun && result.Joined > 0 {
+		RecordLog(u.ID, u.Name, "导入名册", fmt.Sprintf("从 %s 导入名册：加入 %d 人（新建账号 %d 个），跳过 %d 行，失败 %d 行", f.Filename, result.Joined, result.Created, result.Skipped, result.Failed), uint(clubID))
+	}
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+type rosterPassword struct {
+	password, hash string
+}
+
+// rosterGeneratedPasswords 为名册中尚无账号的学号预先生成临时密码及其哈希
+func rosterGeneratedPasswords(rows []roster.Row, enabled bool) (map[string]rosterPassword, error) {
+	out := map[string]rosterPassword{}
+	if !enabled {
+		return out, nil
+	}
+	nos := make([]string, 0, len(rows))
+	for _, r := range rows {
+		if r.StudentNo != "" {
+			nos = append(nos, r.StudentNo)
+		}
+	}
+	var existing []string
+	if err := store.DB().Model(&models.User{}).Where("student_no IN ?", nos).Pluck("student_no", &existing).Error; err != nil {
+		return nil, err
+	}
+	has := make(map[string]bool, len(existing))
+	for _, no := range existing {
+		has[no] = true
+	}
+	for _, no := range nos {
+		if has[no] {
+			continue
+		}
+		if _, ok := out[no]; ok {
+			continue
+		}
+		pw, err := newInviteCode()
+		if err != nil {
+			return nil, err
+		}
+		hash, err := password.Hash(pw)
+		if err != nil {
+			return nil, err
+		}
+		out[no] = rosterPassword{password: pw, hash: hash}
+	}
+	return out, nil
+}
+
+// rosterNameMatches 比较名册姓名与账号姓名，忽略空白与大小写；任一方为空时不校验
+func rosterNameMatches(rosterName, userName string) bool {
+	norm := func(s string) string { return strings.Join(strings.Fields(s), "") }
+	a, b := norm(rosterName), norm(userName)
+	return a == "" || b == "" || strings.EqualFold(a, b)
+}
+
+// rosterRoleLookup 返回名册中角色列可识别的写法（编码或名称，不区分大小写）到角色编码的映射
+func rosterRoleLookup(clubID uint) map[string]string {
+	lookup := map[string]string{}
+	var custom []models.ClubRole
+	_ = store.DB().Where("club_id = ?", clubID).Find(&custom).Error
+	for _, r := range append(append([]models.ClubRole{}, authz.BuiltInRoles...), custom...) {
+		lookup[strings.ToLower(r.Code)] = r.Code
+		lookup[strings.ToLower(r.Name)] = r.Code
+	}
+	return lookup
+}
+
+// rosterGrantableRoles 返回调用者可通过导入授予的角色代码，管理员不受限，其余按 CanGrant 判断
+func rosterGrantableRoles(u *models.User, clubID uint) (map[string]bool, error) {
+	var custom []models.ClubRole
+	_ = store.DB().Where("club_id = ?", clubID).Find(&custom).Error
+	all := append(append([]models.ClubRole{}, authz.BuiltInRoles...), custom...)
+	out := make(map[string]bool, len(all))
+	if authz.IsAdmin(u) {
+		for _, r := range all {
+			out[r.Code] = true
+		}
+		return out, nil
+	}
+	var callerM models.Membership
+	if err := store.DB().Where("user_id = ? AND club_id = ? AND status = ?", u.ID, clubID, "approved").First(&callerM).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range all {
+		out[r.Code] = authz.CanGrant(clubID, callerM.Role, r)
+	}
+	return out, nil
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"web_server/db/models"
+	"web_server/internal/attendance"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SessionTypeReq struct {
+	Code   string   `json:"code" binding:"required"`
+	Name   string   `json:"name" binding:"required"`
+	Weight *float64 `json:"weight"`
+	Ledger string   `json:"ledger"` // volunteer, second_classroom，空表示不计入台账
+}
+
+type ActivityTypeReq struct {
+	Type string `json:"type"` // 考勤类型编码，空表示清除
+}
+
+// @Summary 社团考勤类型列表
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /member/clubs/{clubId}/session-types [get]
+// @Router /leader/clubs/{clubId}/session-types [get]
+func ListSessionTypes(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !(authz.IsAdmin(u) || authz.IsClubMember(u.ID, uint(clubID))) {
+		c.JSON(http.StatusForbidden, response.Error(403, "非社团成员"))
+		return
+	}
+	var list []models.SessionType
+	if err := store.DB().Where("club_id = ?", clubID).Order("id ASC").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(list))
+}
+
+// @Summary 创建考勤类型（负责人）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param payload body SessionTypeReq true "考勤类型"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/session-types [post]
+func CreateSessionType(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	clubID, err := strconv.Atoi(clubIDStr)
+	if err != nil || clubID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req SessionTypeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	st := models.SessionType{ClubID: uint(clubID), Code: req.Code, Name: req.Name, Weight: 1, Ledger: req.Ledger}
+	if req.Weight != nil {
+		st.Weight = *req.Weight
+	}
+	if msg := validateSessionType(st); msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	var cnt int64
+	store.DB().Model(&models.SessionType{}).Where("club_id = ? AND code = ?", clubID, st.Code).Count(&cnt)
+	if cnt > 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "类型编码已存在"))
+		return
+	}
+	if err := store.DB().Create(&st).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("新增考勤类型: %s(%s)", st.Name, st.Code), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(st))
+}
+
+// @Summary 修改考勤类型（负责人）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "考勤类型ID"
+// @Param payload body SessionTypeReq true "考勤类型"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/session-types/{id} [put]
+func UpdateSessionType(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req SessionTypeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var st models.SessionType
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).First(&st).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "考勤类型不存在"))
+		return
+	}
+	if req.Code != st.Code {
+		// 编码被考勤记录与活动引用，不允许修改
+		c.JSON(http.StatusBadRequest, response.Error(400, "类型编码不可修改"))
+		return
+	}
+	st.Name = req.Name
+	st.Ledger = req.Ledger
+	if req.Weight != nil {
+		st.Weight = *req.Weight
+	}
+	if msg := validateSessionType(st); msg != "" {
+		c.JSON(http.StatusBadRequest, response.Error(400, msg))
+		return
+	}
+	if err := store.DB().Save(&st).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("修改考勤类型: %s(%s)", st.Name, st.Code), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(st))
+}
+
+// @Summary 删除考勤类型（负责人）
+// @Tags 考勤
+// @Produce json
+// @Param clubId path int true "社团ID"
+// @Param id path int true "考勤类型ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/clubs/{clubId}/session-types/{id} [delete]
+func DeleteSessionType(c *gin.Context) {
+	clubIDStr := c.Param("clubId")
+	idStr := c.Param("id")
+	clubID, err1 := strconv.Atoi(clubIDStr)
+	id, err2 := strconv.Atoi(idStr)
+	if err1 != nil || err2 != nil || clubID <= 0 || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, uint(clubID), authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	if err := store.DB().Where("id = ? AND club_id = ?", id, clubID).Delete(&models.SessionType{}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("删除考勤类型 %d", id), uint(clubID))
+	c.JSON(http.StatusOK, response.Success(nil))
+}
+
+// @Summary 设置活动默认考勤类型（负责人）
+// @Tags 考勤
+// @Accept json
+// @Produce json
+// @Param activityId path int true "活动ID"
+// @Param payload body ActivityTypeReq true "考勤类型"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /leader/activities/{activityId}/type [put]
+func SetActivitySessionType(c *gin.Context) {
+	activityIDStr := c.Param("activityId")
+	activityID, err := strconv.Atoi(activityIDStr)
+	if err != nil || activityID <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var act models.Activity
+	if err := store.DB().Where("id = ?", activityID).First(&act).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "活动不存在"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.HasClubPermission(u, act.ClubID, authz.PermManageAttendance) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req ActivityTypeReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	if req.Type != "" {
+		if _, err := attendance.ResolveType(act.ClubID, req.Type, nil); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+			return
+		}
+	}
+	if err := store.DB().Model(&act).Update("type", req.Type).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "修改考勤规则", fmt.Sprintf("设置活动 %d 考勤类型为 %s", act.ID, req.Type), act.ClubID)
+	c.JSON(http.StatusOK, response.Success(act))
+}
+
+func validateSessionType(st models.SessionType) string {
+	if len(st.Code) > 32 || len(st.Name) > 64 {
+		return "编码或名称过长"
+	}
+	if st.Weight < 0 {
+		return "权重不能为负数"
+	}
+	if st.Ledger != "" {
+		if _, ok := attendance.Ledgers[st.Ledger]; !ok {
+			return "不支持的台账类型"
+		}
+	}
+	return ""
+}
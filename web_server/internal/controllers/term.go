@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/authz"
+	"web_server/internal/store"
+	"web_server/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TermReq struct {
+	Name      string `json:"name" binding:"required"`
+	StartDate string `json:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate   string `json:"end_date" binding:"required"`   // YYYY-MM-DD
+}
+
+type TermItem struct {
+	models.Term
+	IsCurrent bool `json:"is_current"`
+}
+
+// currentTerm 返回今天所在的学期
+func currentTerm() (*models.Term, error) {
+	var t models.Term
+	today := time.Now().Format("2006-01-02")
+	if err := store.DB().Where("start_date <= ? AND end_date >= ?", today, today).Order("start_date DESC").First(&t).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// termFilter 解析学期筛选参数，支持学期ID、current（当前学期）或 all（不限学期）
+// 参数为空时默认当前学期；已指定日期范围、按学期分组或今天不在任何学期内时不限学期。
+// 返回 nil 表示不限学期；解析失败时已写入响应并返回 ok=false
+func termFilter(c *gin.Context, key string) (term *models.Term, ok bool) {
+	v := c.Query(key)
+	if v == "all" {
+		return nil, true
+	}
+	if v == "" {
+		for _, k := range []string{"start_date", "end_date", "date"} {
+			if c.Query(k) != "" {
+				return nil, true
+			}
+		}
+		if c.Query("group_by") == "term" {
+			return nil, true
+		}
+		t, err := currentTerm()
+		if err != nil {
+			return nil, true
+		}
+		return t, true
+	}
+	if v == "current" {
+		t, err := currentTerm()
+		if err != nil {
+			c.JSON(http.StatusNotFound, response.Error(404, "当前不在任何学期内"))
+			return nil, false
+		}
+		return t, true
+	}
+	id, err := strconv.Atoi(v)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return nil, false
+	}
+	var t models.Term
+	if err := store.DB().Where("id = ?", id).First(&t).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "学期不存在"))
+		return nil, false
+	}
+	return &t, true
+}
+
+// termBounds 返回学期起止时间字符串，结束日期包含当天
+func termBounds(t *models.Term) (string, string) {
+	return t.StartDate.Format("2006-01-02") + " 00:00:00", t.EndDate.Format("2006-01-02") + " 23:59:59"
+}
+
+// termOf 返回时间所在的学期，terms 需按开始日期升序
+func termOf(terms []models.Term, at time.Time) *models.Term {
+	day := at.In(time.Local).Format("2006-01-02")
+	for i := range terms {
+		if terms[i].StartDate.Format("2006-01-02") <= day && terms[i].EndDate.Format("2006-01-02") >= day {
+			return &terms[i]
+		}
+	}
+	return nil
+}
+
+func parseTermReq(req TermReq) (models.Term, error) {
+	start, err1 := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+	end, err2 := time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+	if err1 != nil || err2 != nil {
+		return models.Term{}, errors.New("日期格式应为 YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return models.Term{}, errors.New("结束日期不能早于开始日期")
+	}
+	return models.Term{Name: req.Name, StartDate: start, EndDate: end}, nil
+}
+
+// termOverlaps 检查学期日期是否与其他学期重叠
+func termOverlaps(t models.Term, excludeID uint) bool {
+	var cnt int64
+	store.DB().Model(&models.Term{}).
+		Where("id <> ? AND start_date <= ? AND end_date >= ?", excludeID, t.EndDate.Format("2006-01-02"), t.StartDate.Format("2006-01-02")).
+		Count(&cnt)
+	return cnt > 0
+}
+
+// @Summary 学期列表（公开）
+// @Tags 公共
+// @Produce json
+// @Success 200 {object} response.Body
+// @Router /public/terms [get]
+// @Router /admin/terms [get]
+func ListTerms(c *gin.Context) {
+	var terms []models.Term
+	if err := store.DB().Order("start_date DESC").Find(&terms).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "查询失败"))
+		return
+	}
+	cur, _ := currentTerm()
+	items := make([]TermItem, 0, len(terms))
+	for _, t := range terms {
+		items = append(items, TermItem{Term: t, IsCurrent: cur != nil && cur.ID == t.ID})
+	}
+	c.JSON(http.StatusOK, response.Success(items))
+}
+
+// @Summary 当前学期（公开）
+// @Tags 公共
+// @Produce json
+// @Success 200 {object} response.Body
+// @Router /public/terms/current [get]
+func GetCurrentTerm(c *gin.Context) {
+	t, err := currentTerm()
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "当前不在任何学期内"))
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(t))
+}
+
+// @Summary 创建学期
+// @Tags 管理员
+// @Accept json
+// @Produce json
+// @Param payload body TermReq true "学期信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/terms [post]
+func CreateTerm(c *gin.Context) {
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req TermReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	t, err := parseTermReq(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+		return
+	}
+	if termOverlaps(t, 0) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "学期日期与已有学期重叠"))
+		return
+	}
+	if err := store.DB().Create(&t).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "创建失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "学期管理", fmt.Sprintf("创建学期: %s", t.Name), 0)
+	c.JSON(http.StatusOK, response.Success(t))
+}
+
+// @Summary 修改学期
+// @Tags 管理员
+// @Accept json
+// @Produce json
+// @Param id path int true "学期ID"
+// @Param payload body TermReq true "学期信息"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/terms/{id} [put]
+func UpdateTerm(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	var req TermReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	var t models.Term
+	if err := store.DB().Where("id = ?", id).First(&t).Error; err != nil {
+		c.JSON(http.StatusNotFound, response.Error(404, "学期不存在"))
+		return
+	}
+	nt, err := parseTermReq(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error(400, err.Error()))
+		return
+	}
+	if termOverlaps(nt, t.ID) {
+		c.JSON(http.StatusBadRequest, response.Error(400, "学期日期与已有学期重叠"))
+		return
+	}
+	t.Name, t.StartDate, t.EndDate = nt.Name, nt.StartDate, nt.EndDate
+	if err := store.DB().Save(&t).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "更新失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "学期管理", fmt.Sprintf("修改学期 %d: %s", t.ID, t.Name), 0)
+	c.JSON(http.StatusOK, response.Success(t))
+}
+
+// @Summary 删除学期
+// @Tags 管理员
+// @Produce json
+// @Param id path int true "学期ID"
+// @Security Bearer
+// @Success 200 {object} response.Body
+// @Router /admin/terms/{id} [delete]
+func DeleteTerm(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, response.Error(400, "参数错误"))
+		return
+	}
+	cu, _ := c.Get("currentUser")
+	u := cu.(*models.User)
+	if !authz.IsAdmin(u) {
+		c.JSON(http.StatusForbidden, response.Error(403, "无权限"))
+		return
+	}
+	if err := store.DB().Delete(&models.Term{}, id).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, response.Error(500, "删除失败"))
+		return
+	}
+	RecordLog(u.ID, u.Name, "学期管理", fmt.Sprintf("删除学期 %d", id), 0)
+	c.JSON(http.StatusOK, response.Success(nil))
+}
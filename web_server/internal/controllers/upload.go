@@ -19,6 +19,7 @@ import (
 // @Security Bearer
 // @Success 200 {object} response.Body
 // @Router /upload/image [post]
+// @Router /public/upload/image [post]
 func UploadImage(c *gin.Context) {
 	f, err := c.FormFile("file")
 	if err != nil {
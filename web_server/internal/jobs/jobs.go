@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"time"
+	"web_server/internal/attendance"
+	"web_server/internal/membership"
+	"web_server/pkg/logger"
+)
+
+// Start 启动后台定时任务，interval 为最短执行间隔
+func Start(interval time.Duration) {
+	every(interval, processAttendance)
+	every(time.Hour, processMemberships)
+}
+
+// every 立即执行一次，之后按间隔重复执行
+func every(interval time.Duration, fn func(now time.Time)) {
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			fn(time.Now())
+			<-t.C
+		}
+	}()
+}
+
+func processAttendance(now time.Time) {
+	if _, err := attendance.ProcessEndedSessions(now); err != nil {
+		logger.Error("process mandatory sessions error:", err)
+	}
+}
+
+func processMemberships(now time.Time) {
+	if _, err := membership.BackfillExpiry(now); err != nil {
+		logger.Error("backfill membership expiry error:", err)
+	}
+	if _, err := membership.RemindRenewals(now); err != nil {
+		logger.Error("renewal reminder error:", err)
+	}
+	if _, err := membership.ExpireMemberships(now); err != nil {
+		logger.Error("expire memberships error:", err)
+	}
+}
@@ -0,0 +1,33 @@
+package membership
+
+import (
+	"web_server/db/models"
+
+	"gorm.io/gorm"
+)
+
+// recordEvent 追加一条成员关系变更记录，reason 超长时截断
+func recordEvent(db *gorm.DB, m *models.Membership, fromStatus, fromRole string, actorID uint, reason string) error {
+	if r := []rune(reason); len(r) > 255 {
+		reason = string(r[:255])
+	}
+	return db.Create(&models.MembershipEvent{
+		MembershipID: m.ID,
+		ClubID:       m.ClubID,
+		UserID:       m.UserID,
+		ActorID:      actorID,
+		FromStatus:   fromStatus,
+		ToStatus:     m.Status,
+		FromRole:     fromRole,
+		ToRole:       m.Role,
+		Reason:       reason,
+	}).Error
+}
+
+// RecordRoleChange 记录状态不变的角色调整，调用方在保存 m 的同一事务中调用
+func RecordRoleChange(db *gorm.DB, m *models.Membership, fromRole string, actorID uint, reason string) error {
+	if fromRole == m.Role {
+		return nil
+	}
+	return recordEvent(db, m, m.Status, fromRole, actorID, reason)
+}
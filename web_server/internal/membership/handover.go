@@ -0,0 +1,105 @@
+package membership
+
+import (
+	"errors"
+	"time"
+	"web_server/db/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrHandoverClosed   = errors.New("交接已结束")
+	ErrHandoverStale    = errors.New("发起人已不是社长，交接失效")
+	ErrSuccessorInvalid = errors.New("继任者已不是社团正式成员")
+)
+
+// OpenHandoverStatuses 进行中的交接状态，同一社团同时只允许一条
+var OpenHandoverStatuses = []string{"pending", "accepted"}
+
+// AcceptHandover 继任者接受交接；社团要求管理员确认时仅标记为 accepted，否则直接完成
+func AcceptHandover(tx *gorm.DB, h *models.LeaderHandover, now time.Time) (bool, error) {
+	if h.Status != "pending" {
+		return false, ErrHandoverClosed
+	}
+	h.AcceptedAt = &now
+	if !h.RequireAdmin {
+		return true, CompleteHandover(tx, h, now)
+	}
+	res := tx.Model(&models.LeaderHandover{}).Where("id = ? AND status = ?", h.ID, "pending").
+		Updates(map[string]any{"status": "accepted", "accepted_at": now})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, ErrHandoverClosed
+	}
+	h.Status = "accepted"
+	return false, nil
+}
+
+// CompleteHandover 在事务内交换角色：继任者成为社长，原社长转为普通成员或退社成为校友
+func CompleteHandover(tx *gorm.DB, h *models.LeaderHandover, now time.Time) error {
+	var from, to models.Membership
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND club_id = ? AND role = ? AND status = ?", h.FromUserID, h.ClubID, "leader", "approved").
+		First(&from).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrHandoverStale
+		}
+		return err
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND club_id = ? AND status = ?", h.ToUserID, h.ClubID, "approved").
+		First(&to).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSuccessorInvalid
+		}
+		return err
+	}
+	// 条件更新，防止同一交接被重复完成
+	res := tx.Model(&models.LeaderHandover{}).Where("id = ? AND status IN ?", h.ID, OpenHandoverStatuses).
+		Updates(map[string]any{"status": "completed", "accepted_at": h.AcceptedAt, "completed_at": now, "confirmed_by": h.ConfirmedBy})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrHandoverClosed
+	}
+	var cl models.Club
+	if err := tx.Where("id = ?", h.ClubID).First(&cl).Error; err != nil {
+		return err
+	}
+	actorID := h.ToUserID
+	if h.ConfirmedBy != 0 {
+		actorID = h.ConfirmedBy
+	}
+	if h.OutgoingRole == "alumni" {
+		from.Role, from.ExpiresAt, from.RenewalStatus = "member", nil, ""
+		if err := TransitWithReason(tx, &from, "quit", actorID, now, "社长交接后退社"); err != nil {
+			return err
+		}
+	} else {
+		from.Role, from.ExpiresAt, from.RenewalStatus = "member", ExpiryFrom(cl, now), ""
+		NoteRole(&from)
+		if err := tx.Model(&from).Select("role", "expires_at", "renewal_status", "roles_held").Updates(&from).Error; err != nil {
+			return err
+		}
+		if err := RecordRoleChange(tx, &from, "leader", actorID, "社长交接"); err != nil {
+			return err
+		}
+	}
+	toRole := to.Role
+	to.Role, to.ExpiresAt, to.RenewalStatus, to.RenewalRequestedAt = "leader", nil, "", nil
+	NoteRole(&to)
+	if err := tx.Model(&to).Select("role", "expires_at", "renewal_status", "renewal_requested_at", "roles_held").Updates(&to).Error; err != nil {
+		return err
+	}
+	if err := RecordRoleChange(tx, &to, toRole, actorID, "社长交接"); err != nil {
+		return err
+	}
+	h.Status = "completed"
+	h.CompletedAt = &now
+	return nil
+}
@@ -0,0 +1,81 @@
+package membership
+
+import (
+	"time"
+	"web_server/db/models"
+)
+
+// AlumniStatuses 计入校友名录的成员状态，被移出的成员不展示
+var AlumniStatuses = []string{"quit", "expired"}
+
+// NoteRole 正式成员的当前角色记入 RolesHeld，重复的角色只记一次
+func NoteRole(m *models.Membership) {
+	if m.Status != "approved" || m.Role == "" {
+		return
+	}
+	for _, r := range m.RolesHeld {
+		if r == m.Role {
+			return
+		}
+	}
+	m.RolesHeld = append(m.RolesHeld, m.Role)
+}
+
+// JoinedSince 返回加入时间，从未通过审核时返回 nil；早于该字段上线的记录退回创建时间
+func JoinedSince(m models.Membership) *time.Time {
+	if m.JoinedAt != nil {
+		return m.JoinedAt
+	}
+	if m.Status == "pending" || m.Status == "rejected" {
+		return nil
+	}
+	t := m.CreatedAt
+	return &t
+}
+
+// LeftSince 返回最近一次离开的时间，仍在社或从未离开时返回 nil；早期记录退回最近一次状态变更时间
+func LeftSince(m models.Membership) *time.Time {
+	if m.LeftAt != nil || m.Status == "pending" || m.Status == "rejected" {
+		return m.LeftAt
+	}
+	if m.Status == "approved" {
+		return nil
+	}
+	if m.StatusChangedAt != nil {
+		return m.StatusChangedAt
+	}
+	t := m.UpdatedAt
+	return &t
+}
+
+// ActiveDuring 判断成员关系是否与 [from, to) 期间重叠：期间结束前加入，且仍在社或在期间开始后才离开；
+// 审核中的申请从提交起计入，已驳回的申请按驳回时间计入
+func ActiveDuring(m models.Membership, from, to time.Time) bool {
+	if joined := JoinedSince(m); joined != nil && joined.Before(to) {
+		if left := LeftSince(m); left == nil || !left.Before(from) {
+			return true
+		}
+	}
+	at := m.CreatedAt
+	if m.StatusChangedAt != nil {
+		at = *m.StatusChangedAt
+	}
+	switch m.Status {
+	case "pending":
+		return at.Before(to)
+	case "rejected":
+		return !at.Before(from) && at.Before(to)
+	}
+	return false
+}
+
+// RolesOf 返回担任过的角色，早期记录只有当前角色
+func RolesOf(m models.Membership) []string {
+	if len(m.RolesHeld) > 0 {
+		return m.RolesHeld
+	}
+	if m.Role == "" {
+		return []string{}
+	}
+	return []string{m.Role}
+}
@@ -0,0 +1,171 @@
+package membership
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"web_server/db/models"
+	"web_server/internal/store"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotRenewable   = errors.New("当前不在续期时间内")
+	ErrRenewalPending = errors.New("续期申请待审核")
+	ErrNoExpiry       = errors.New("社团成员资格长期有效，无需续期")
+)
+
+var leaderRoles = []string{"leader", "advisor"}
+
+// 续期提醒查询的最大窗口
+const maxRenewalWindowDays = 366
+
+// IsLeaderRole 负责人与指导老师的成员资格不会到期
+func IsLeaderRole(role string) bool {
+	for _, r := range leaderRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpiryFrom 按社团有效期计算到期时间，长期有效时返回 nil
+func ExpiryFrom(cl models.Club, from time.Time) *time.Time {
+	if cl.MembershipMonths <= 0 {
+		return nil
+	}
+	t := from.AddDate(0, cl.MembershipMonths, 0)
+	return &t
+}
+
+// InRenewalWindow 判断成员当前能否续期：已到期，或在到期前的续期窗口内
+func InRenewalWindow(cl models.Club, m models.Membership, now time.Time) bool {
+	if m.Status == "expired" {
+		return true
+	}
+	if m.Status != "approved" || m.ExpiresAt == nil {
+		return false
+	}
+	return !now.Before(m.ExpiresAt.AddDate(0, 0, -cl.RenewalWindowDays))
+}
+
+// CheckRenewable 校验续期申请
+func CheckRenewable(cl models.Club, m models.Membership, now time.Time) error {
+	if cl.MembershipMonths <= 0 {
+		return ErrNoExpiry
+	}
+	if m.RenewalStatus == "pending" {
+		return ErrRenewalPending
+	}
+	if !InRenewalWindow(cl, m, now) {
+		return ErrNotRenewable
+	}
+	return nil
+}
+
+// Renew 续期一个有效期，未到期时从原到期时间顺延，已到期的成员恢复为正式成员
+func Renew(db *gorm.DB, cl models.Club, m *models.Membership, actorID uint, now time.Time) error {
+	base := now
+	if m.Status == "approved" && m.ExpiresAt != nil && m.ExpiresAt.After(now) {
+		base = *m.ExpiresAt
+	}
+	m.ExpiresAt = ExpiryFrom(cl, base)
+	m.RenewalStatus = ""
+	m.RenewalRequestedAt = nil
+	if m.Status != "approved" {
+		return TransitWithReason(db, m, "approved", actorID, now, "续期")
+	}
+	return db.Model(m).Updates(map[string]any{"expires_at": m.ExpiresAt, "renewal_status": "", "renewal_requested_at": nil}).Error
+}
+
+// BackfillExpiry 为尚无到期时间的成员按加入时间补齐到期时间，至少保留一个续期窗口，避免启用后立即到期
+func BackfillExpiry(now time.Time) (int, error) {
+	var list []models.Membership
+	if err := store.DB().Preload("Club").
+		Where("status = ? AND expires_at IS NULL AND role NOT IN ?", "approved", leaderRoles).
+		Find(&list).Error; err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, m := range list {
+		joined := m.CreatedAt
+		if t := JoinedSince(m); t != nil {
+			joined = *t
+		}
+		exp := ExpiryFrom(m.Club, joined)
+		if exp == nil {
+			continue
+		}
+		if earliest := now.AddDate(0, 0, m.Club.RenewalWindowDays); exp.Before(earliest) {
+			exp = &earliest
+		}
+		if err := store.DB().Model(&models.Membership{}).Where("id = ?", m.ID).Update("expires_at", *exp).Error; err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// RemindRenewals 对进入续期窗口的成员发送一次续期提醒
+func RemindRenewals(now time.Time) (int, error) {
+	var list []models.Membership
+	if err := store.DB().Preload("Club").
+		Where("status = ? AND role NOT IN ? AND renewal_status = ? AND expires_at > ? AND expires_at <= ?",
+			"approved", leaderRoles, "", now, now.AddDate(0, 0, maxRenewalWindowDays)).
+		Find(&list).Error; err != nil {
+		return 0, err
+	}
+	var notes []models.Notification
+	for _, m := range list {
+		if m.Club.MembershipMonths <= 0 || !InRenewalWindow(m.Club, m, now) {
+			continue
+		}
+		notes = append(notes, models.Notification{
+			UserID:  m.UserID,
+			ClubID:  m.ClubID,
+			Kind:    "renewal_reminder",
+			Title:   "社团成员资格即将到期",
+			Content: fmt.Sprintf("您在「%s」的成员资格将于 %s 到期，请及时续期。", m.Club.Name, m.ExpiresAt.Format("2006-01-02")),
+			RefKey:  fmt.Sprintf("renewal:%d:%s", m.ID, m.ExpiresAt.Format("20060102")),
+		})
+	}
+	if len(notes) == 0 {
+		return 0, nil
+	}
+	res := store.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&notes)
+	return int(res.RowsAffected), res.Error
+}
+
+// ExpireMemberships 将到期未续期的成员置为 expired，负责人除外
+func ExpireMemberships(now time.Time) (int, error) {
+	var list []models.Membership
+	if err := store.DB().Preload("Club").
+		Where("status = ? AND role NOT IN ? AND expires_at IS NOT NULL AND expires_at <= ?", "approved", leaderRoles, now).
+		Find(&list).Error; err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, m := range list {
+		// 以 approved 为条件流转，与同时进行的续期冲突时跳过
+		if err := TransitWithReason(store.DB(), &m, "expired", 0, now, "到期未续期"); err != nil {
+			if errors.Is(err, ErrStatusChanged) {
+				continue
+			}
+			return n, err
+		}
+		n++
+		store.DB().Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Notification{
+			UserID:  m.UserID,
+			ClubID:  m.ClubID,
+			Kind:    "membership_expired",
+			Title:   "社团成员资格已到期",
+			Content: fmt.Sprintf("您在「%s」的成员资格已到期，可在社团页面申请续期。", m.Club.Name),
+			RefKey:  fmt.Sprintf("expired:%d:%s", m.ID, m.ExpiresAt.Format("20060102")),
+		})
+	}
+	return n, nil
+}
@@ -0,0 +1,167 @@
+package membership
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"web_server/config"
+	"web_server/db/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStatusChanged 并发修改导致状态已不是读取时的值
+var ErrStatusChanged = &StateError{Msg: "成员状态已变更，请刷新后重试"}
+
+// StateError 状态流转被拒绝，错误信息可直接返回给用户
+type StateError struct {
+	Msg string
+}
+
+func (e *StateError) Error() string { return e.Msg }
+
+var statusLabels = map[string]string{
+	"":         "非成员",
+	"pending":  "待审核",
+	"approved": "正式成员",
+	"rejected": "已驳回",
+	"quit":     "已退出",
+	"kicked":   "已移出",
+	"expired":  "已到期",
+}
+
+// transitions 允许的状态流转，空字符串表示尚无成员记录
+var transitions = map[string][]string{
+	"":         {"pending", "approved"},
+	"pending":  {"approved", "rejected"},
+	"approved": {"quit", "kicked", "expired"},
+	"rejected": {"pending", "approved"},
+	"quit":     {"pending", "approved"},
+	"kicked":   {"pending", "approved"},
+	"expired":  {"approved", "pending", "quit", "kicked"},
+}
+
+// CanTransit 判断是否允许从 from 流转到 to
+func CanTransit(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+func transitError(from, to string) *StateError {
+	switch {
+	case from == "approved" && (to == "pending" || to == "approved"):
+		return &StateError{Msg: "已是社团成员"}
+	case from == "pending" && to == "pending":
+		return &StateError{Msg: "申请审核中，请勿重复提交"}
+	case from == "pending" && to == "quit":
+		return &StateError{Msg: "申请尚未通过，无需退出"}
+	}
+	return &StateError{Msg: fmt.Sprintf("成员状态为「%s」，不能变更为「%s」", statusLabels[from], statusLabels[to])}
+}
+
+// cooldownUntil 被驳回或移出后本人再次加入的最早时间，无冷却期时返回 nil
+func cooldownUntil(m *models.Membership) *time.Time {
+	var days int
+	switch m.Status {
+	case "rejected":
+		days = config.Default().Membership.RejectCooldownDays
+	case "kicked":
+		days = config.Default().Membership.KickCooldownDays
+	}
+	if days <= 0 {
+		return nil
+	}
+	since := m.UpdatedAt
+	if m.StatusChangedAt != nil {
+		since = *m.StatusChangedAt
+	}
+	t := since.AddDate(0, 0, days)
+	return &t
+}
+
+// Transit 校验并执行一次状态流转，同时保存调用方对 m 其他字段的修改。
+// actorID 为操作人，等于成员本人时（申请、兑换邀请码）检查驳回/移出后的冷却期；
+// 更新以读取时的状态为条件，并发流转时返回 ErrStatusChanged。
+// 流转与对应的变更记录在同一事务中写入，驳回时以驳回原因作为记录原因。
+func Transit(db *gorm.DB, m *models.Membership, to string, actorID uint, now time.Time) error {
+	reason := ""
+	if to == "rejected" {
+		reason = m.RejectReason
+	}
+	return TransitWithReason(db, m, to, actorID, now, reason)
+}
+
+// TransitWithReason 同 Transit，reason 写入变更记录
+func TransitWithReason(db *gorm.DB, m *models.Membership, to string, actorID uint, now time.Time, reason string) error {
+	from := m.Status
+	if m.ID == 0 {
+		from = ""
+	}
+	if !CanTransit(from, to) {
+		return transitError(from, to)
+	}
+	if actorID == m.UserID && (to == "pending" || to == "approved") {
+		if until := cooldownUntil(m); until != nil && now.Before(*until) {
+			return &StateError{Msg: fmt.Sprintf("%s后才能再次加入该社团", until.Format("2006-01-02 15:04"))}
+		}
+	}
+	snapshot := *m
+	m.Status = to
+	m.StatusChangedAt = &now
+	m.StatusChangedBy = actorID
+	if to != "rejected" {
+		m.RejectReason = ""
+	}
+	switch {
+	case to == "approved":
+		// 到期后续期视为同一段在社经历
+		if from != "expired" || m.JoinedAt == nil {
+			m.JoinedAt = &now
+		}
+		m.LeftAt = nil
+		NoteRole(m)
+	case from == "approved":
+		m.LeftAt = &now
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		fromRole := ""
+		if from == "" {
+			if err := tx.Omit(clause.Associations).Create(m).Error; err != nil {
+				return err
+			}
+		} else {
+			// 调用方可能已改写 m.Role，变更前的角色以库中为准
+			var roles []string
+			if err := tx.Model(&models.Membership{}).Where("id = ?", m.ID).Pluck("role", &roles).Error; err != nil {
+				return err
+			}
+			if len(roles) > 0 {
+				fromRole = roles[0]
+			}
+			res := tx.Model(m).Where("status = ?", from).Select("*").Omit("created_at", clause.Associations).Updates(m)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return ErrStatusChanged
+			}
+		}
+		return recordEvent(tx, m, from, fromRole, actorID, reason)
+	})
+	if err != nil {
+		*m = snapshot
+		return err
+	}
+	return nil
+}
+
+// IsStateError 判断是否为可直接提示用户的状态流转错误
+func IsStateError(err error) bool {
+	var se *StateError
+	return errors.As(err, &se)
+}
@@ -0,0 +1,279 @@
+// Package roster 解析社团名册导入文件（CSV/XLSX）
+package roster
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MaxRows 单次导入的最大行数
+const MaxRows = 2000
+
+var (
+	ErrUnsupported = errors.New("仅支持 .csv 或 .xlsx 文件")
+	ErrNoHeader    = errors.New("缺少表头，至少需要“学号”列")
+	ErrTooManyRows = fmt.Errorf("单次最多导入 %d 行", MaxRows)
+)
+
+// Row 名册中的一行，Line 为文件中的行号（含表头，从 1 开始）
+type Row struct {
+	Line      int    `json:"line"`
+	StudentNo string `json:"student_no"`
+	Name      string `json:"name"`
+	College   string `json:"college"`
+	Phone     string `json:"phone"`
+	Role      string `json:"role"`
+}
+
+// 表头别名，不区分大小写
+var headerAliases = map[string]string{
+	"学号": "student_no", "student_no": "student_no", "studentno": "student_no",
+	"姓名": "name", "name": "name",
+	"学院": "college", "college": "college",
+	"手机": "phone", "手机号": "phone", "电话": "phone", "联系电话": "phone", "phone": "phone",
+	"角色": "role", "role": "role",
+}
+
+// Parse 按文件扩展名解析名册
+func Parse(filename string, data []byte) ([]Row, error) {
+	var records [][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		records, err = readCSV(data)
+	case ".xlsx":
+		records, err = readXLSX(data)
+	default:
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRows(records)
+}
+
+func toRows(records [][]string) ([]Row, error) {
+	if len(records) == 0 {
+		return nil, ErrNoHeader
+	}
+	cols := map[string]int{}
+	for i, h := range records[0] {
+		if key, ok := headerAliases[strings.ToLower(strings.TrimSpace(h))]; ok {
+			if _, dup := cols[key]; !dup {
+				cols[key] = i
+			}
+		}
+	}
+	if _, ok := cols["student_no"]; !ok {
+		return nil, ErrNoHeader
+	}
+	get := func(rec []string, key string) string {
+		i, ok := cols[key]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+	rows := make([]Row, 0, len(records)-1)
+	for i, rec := range records[1:] {
+		r := Row{
+			Line:      i + 2,
+			StudentNo: get(rec, "student_no"),
+			Name:      get(rec, "name"),
+			College:   get(rec, "college"),
+			Phone:     get(rec, "phone"),
+			Role:      get(rec, "role"),
+		}
+		if r == (Row{Line: r.Line}) {
+			continue // 跳过空行
+		}
+		rows = append(rows, r)
+		if len(rows) > MaxRows {
+			return nil, ErrTooManyRows
+		}
+	}
+	return rows, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("CSV 格式错误：%v", err)
+	}
+	return records, nil
+}
+
+// XLSX 解析所需的最小结构
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxRichText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.R) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.R {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX 读取工作簿第一个工作表
+func readXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("XLSX 文件已损坏")
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeZipXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	var sheet xlsxSheet
+	if err := decodeZipXML(files[sheetPath], &sheet); err != nil {
+		return nil, err
+	}
+	records := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var rec []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				col = columnIndex(cell.Ref)
+			}
+			if col < 0 || col > maxXLSXColumn {
+				return nil, errors.New("XLSX 文件已损坏")
+			}
+			for len(rec) <= col {
+				rec = append(rec, "")
+			}
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, errors.New("XLSX 共享字符串索引错误")
+				}
+				rec[col] = shared.Items[idx].String()
+			case "inlineStr":
+				rec[col] = cell.Inline.String()
+			default:
+				rec[col] = cell.Value
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// firstSheetPath 通过 workbook.xml 及其关系文件定位第一个工作表，找不到时退回 sheet1.xml
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+	var wb xlsxWorkbook
+	var rels xlsxRelationships
+	wf, ok1 := files["xl/workbook.xml"]
+	rf, ok2 := files["xl/_rels/workbook.xml.rels"]
+	if ok1 && ok2 && decodeZipXML(wf, &wb) == nil && decodeZipXML(rf, &rels) == nil && len(wb.Sheets) > 0 {
+		for _, r := range rels.Items {
+			if r.ID != wb.Sheets[0].RID {
+				continue
+			}
+			p := strings.TrimPrefix(r.Target, "/")
+			if !strings.HasPrefix(p, "xl/") {
+				p = path.Join("xl", p)
+			}
+			if _, ok := files[p]; ok {
+				return p, nil
+			}
+		}
+	}
+	if _, ok := files[fallback]; ok {
+		return fallback, nil
+	}
+	return "", errors.New("XLSX 中没有工作表")
+}
+
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return errors.New("XLSX 文件已损坏")
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, 32<<20)).Decode(v); err != nil {
+		return errors.New("XLSX 文件已损坏")
+	}
+	return nil
+}
+
+// maxXLSXColumn XLSX 最大列 XFD 对应的列号
+const maxXLSXColumn = 16383
+
+// columnIndex 将单元格引用（如 "AB12"）的列字母转为从 0 开始的列号，
+// 缺少列字母或超过 XFD 时返回 -1
+func columnIndex(ref string) int {
+	n := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		n = n*26 + int(ch-'A'+1)
+		if n > maxXLSXColumn+1 {
+			return -1
+		}
+	}
+	return n - 1
+}
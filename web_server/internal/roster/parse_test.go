@@ -0,0 +1,202 @@
+package roster
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// buildXLSX 构造只含必要部件的 XLSX，files 为包内路径到内容的映射
+func buildXLSX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+const sheetNS = `xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"`
+
+func sheet(rows string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?><worksheet ` + sheetNS + `><sheetData>` + rows + `</sheetData></worksheet>`
+}
+
+func TestColumnIndex(t *testing.T) {
+	cases := []struct {
+		ref  string
+		want int
+	}{
+		{"A1", 0},
+		{"B7", 1},
+		{"Z1", 25},
+		{"AA1", 26},
+		{"AB12", 27},
+		{"AZ3", 51},
+		{"BA3", 52},
+		{"ZZ1", 701},
+		{"AAA1", 702},
+		{"XFD1", 16383},
+		{"XFE1", -1},
+		{"ZZZZZZZ1", -1},
+		{"ZZZZZZZZZZZZZZZZZZZZZZZZ1", -1},
+		{"12", -1},
+	}
+	for _, tc := range cases {
+		if got := columnIndex(tc.ref); got != tc.want {
+			t.Errorf("columnIndex(%q) = %d, want %d", tc.ref, got, tc.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	shared := `<?xml version="1.0" encoding="UTF-8"?><sst ` + sheetNS + `>` +
+		`<si><t>学号</t></si><si><t>姓名</t></si><si><t>角色</t></si>` +
+		`<si><r><t>张</t></r><r><t>三</t></r></si>` + // 富文本分段
+		`<si><t>成员</t></si></sst>`
+
+	cases := []struct {
+		name     string
+		filename string
+		data     []byte
+		want     []Row
+		wantErr  error
+	}{
+		{
+			name:     "csv with bom and aliases",
+			filename: "roster.CSV",
+			data:     []byte("\xef\xbb\xbf学号,姓名,学院,手机号,Role\n2021001,张三,计算机学院,13800000000,成员\n"),
+			want:     []Row{{Line: 2, StudentNo: "2021001", Name: "张三", College: "计算机学院", Phone: "13800000000", Role: "成员"}},
+		},
+		{
+			name:     "csv skips blank rows but keeps line numbers",
+			filename: "r.csv",
+			data:     []byte("student_no,name\n001,A\n,\n003,C\n"),
+			want:     []Row{{Line: 2, StudentNo: "001", Name: "A"}, {Line: 4, StudentNo: "003", Name: "C"}},
+		},
+		{
+			name:     "csv short rows",
+			filename: "r.csv",
+			data:     []byte("姓名,学号,角色\n李四,002\n"),
+			want:     []Row{{Line: 2, StudentNo: "002", Name: "李四"}},
+		},
+		{
+			name:     "missing student number header",
+			filename: "r.csv",
+			data:     []byte("姓名\n张三\n"),
+			wantErr:  ErrNoHeader,
+		},
+		{
+			name:     "empty file",
+			filename: "r.csv",
+			data:     []byte(""),
+			wantErr:  ErrNoHeader,
+		},
+		{
+			name:     "unsupported extension",
+			filename: "r.xls",
+			data:     []byte("x"),
+			wantErr:  ErrUnsupported,
+		},
+		{
+			name:     "xlsx shared, rich and inline strings",
+			filename: "r.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/sharedStrings.xml": shared,
+				"xl/worksheets/sheet1.xml": sheet(
+					`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+						`<row r="2"><c r="A2"><v>2021001</v></c><c r="B2" t="s"><v>3</v></c><c r="C2" t="s"><v>4</v></c></row>` +
+						`<row r="3"><c r="A3" t="inlineStr"><is><t>2021002</t></is></c><c r="B3" t="str"><v>王五</v></c></row>`),
+			}),
+			want: []Row{
+				{Line: 2, StudentNo: "2021001", Name: "张三", Role: "成员"},
+				{Line: 3, StudentNo: "2021002", Name: "王五"},
+			},
+		},
+		{
+			name:     "xlsx sparse cells use references",
+			filename: "r.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/worksheets/sheet1.xml": sheet(
+					`<row r="1"><c r="A1" t="inlineStr"><is><t>学号</t></is></c><c r="D1" t="inlineStr"><is><t>手机</t></is></c></row>` +
+						`<row r="2"><c r="D2" t="inlineStr"><is><t>139</t></is></c><c r="A2"><v>7</v></c></row>`),
+			}),
+			want: []Row{{Line: 2, StudentNo: "7", Phone: "139"}},
+		},
+		{
+			name:     "xlsx first sheet resolved through workbook relationships",
+			filename: "r.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/workbook.xml": `<workbook ` + sheetNS + ` xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+					`<sheets><sheet name="名单" sheetId="1" r:id="rId5"/><sheet name="其他" sheetId="2" r:id="rId1"/></sheets></workbook>`,
+				"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+					`<Relationship Id="rId1" Target="worksheets/sheet1.xml"/><Relationship Id="rId5" Target="/xl/worksheets/roster.xml"/></Relationships>`,
+				"xl/worksheets/sheet1.xml": sheet(`<row r="1"><c r="A1" t="inlineStr"><is><t>学号</t></is></c></row><row r="2"><c r="A2"><v>999</v></c></row>`),
+				"xl/worksheets/roster.xml": sheet(`<row r="1"><c r="A1" t="inlineStr"><is><t>学号</t></is></c></row><row r="2"><c r="A2"><v>123</v></c></row>`),
+			}),
+			want: []Row{{Line: 2, StudentNo: "123"}},
+		},
+		{
+			name:     "xlsx bad shared string index",
+			filename: "r.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/worksheets/sheet1.xml": sheet(`<row r="1"><c r="A1" t="s"><v>5</v></c></row>`),
+			}),
+			wantErr: errors.New("XLSX 共享字符串索引错误"),
+		},
+		{
+			name:     "xlsx column beyond XFD",
+			filename: "r.xlsx",
+			data: buildXLSX(t, map[string]string{
+				"xl/worksheets/sheet1.xml": sheet(`<row r="1"><c r="A1" t="inlineStr"><is><t>学号</t></is></c><c r="ZZZZZZZ1"><v>1</v></c></row>`),
+			}),
+			wantErr: errors.New("XLSX 文件已损坏"),
+		},
+		{
+			name:     "xlsx not a zip",
+			filename: "r.xlsx",
+			data:     []byte("not a zip"),
+			wantErr:  errors.New("XLSX 文件已损坏"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.filename, tc.data)
+			if tc.wantErr != nil {
+				if err == nil || err.Error() != tc.wantErr.Error() {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("rows = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseTooManyRows(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("学号\n")
+	for i := 0; i <= MaxRows; i++ {
+		buf.WriteString("1\n")
+	}
+	if _, err := Parse("r.csv", buf.Bytes()); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("err = %v, want ErrTooManyRows", err)
+	}
+}
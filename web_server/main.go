@@ -4,11 +4,13 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 	"web_server/api"
 	"web_server/config"
 	"web_server/db"
 	"web_server/db/migrate"
 	"web_server/docs"
+	"web_server/internal/jobs"
 	"web_server/internal/store"
 	"web_server/pkg/logger"
 
@@ -43,6 +45,10 @@ func main() {
 	if err := migrate.MigrateAttendanceActivityNullable(d); err != nil {
 		logger.Error("migrate attendance activity nullable error:", err)
 	}
+	if err := migrate.BackfillWeightedHours(d); err != nil {
+		logger.Error("backfill weighted hours error:", err)
+	}
+	jobs.Start(time.Minute)
 
 	r := gin.Default()
 	pubPath := filepath.Join(cfg.Server.PublicDir)